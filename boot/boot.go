@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 	"go.quinn.io/dataq/cas"
 	"go.quinn.io/dataq/config"
+	"go.quinn.io/dataq/fixture"
 	"go.quinn.io/dataq/index"
 	"go.quinn.io/dataq/internal/repo"
 )
@@ -29,8 +32,7 @@ func New() (*Boot, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config: %w", err)
 	}
-	config.StateDir()
-	db, err := sql.Open("sqlite3", config.StateDir()+"/state.db")
+	db, backend, err := openIndexDB(cfg.Index)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -55,7 +57,7 @@ func New() (*Boot, error) {
 	// }
 
 	// Initialize index
-	idx := index.NewIndex(pk, db)
+	idx := index.NewIndexWithBackend(pk, db, backend)
 
 	// queueItems, err := q.List(context.Background(), "")
 	// if err != nil {
@@ -76,6 +78,15 @@ func New() (*Boot, error) {
 
 	repo := repo.NewRepo(idx)
 
+	// Extracts are recorded or replayed while developing plugins
+	fixtures, err := fixture.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if fixtures != nil {
+		log.Printf("Extract fixtures: %s %s", fixtures.Mode, fixtures.Dir)
+	}
+
 	return &Boot{
 		Config: cfg,
 		Index:  idx,
@@ -83,11 +94,30 @@ func New() (*Boot, error) {
 		// Tree:   t,
 		// Worker:  wrkr,
 		CAS:     pk,
-		Plugins: NewPluginManager(idx, pk, repo),
+		Plugins: NewPluginManager(idx, pk, repo, fixtures),
 		Repo:    repo,
 	}, nil
 }
 
+// openIndexDB opens the database configured for the index
+func openIndexDB(cfg config.Index) (*sql.DB, index.Backend, error) {
+	switch cfg.Driver {
+	case "", "sqlite":
+		dsn := cfg.DSN
+		if dsn == "" {
+			dsn = config.StateDir() + "/state.db"
+		}
+
+		db, err := sql.Open("sqlite3", dsn)
+		return db, index.SQLite{}, err
+	case "postgres":
+		db, err := sql.Open("postgres", cfg.DSN)
+		return db, index.Postgres{}, err
+	default:
+		return nil, nil, fmt.Errorf("unknown index driver: %q", cfg.Driver)
+	}
+}
+
 // StartPlugins initializes and starts all enabled plugins
 func (b *Boot) StartPlugins(ctx context.Context) error {
 	// claims, err := b.Repo.PluginClaims(ctx)
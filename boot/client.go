@@ -3,12 +3,21 @@ package boot
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.quinn.io/dataq/cas"
+	"go.quinn.io/dataq/fixture"
 	"go.quinn.io/dataq/index"
 	"go.quinn.io/dataq/internal/repo"
 	"go.quinn.io/dataq/rpc"
@@ -17,25 +26,76 @@ import (
 
 // DataQClient wraps the gRPC client with index-based request hash handling
 type DataQClient struct {
+	mu     sync.RWMutex
+	conn   *grpc.ClientConn
 	client rpc.DataQPluginClient
-	index  *index.Index
-	cas    cas.Storage
-	repo   *repo.Repo
+
+	index *index.Index
+	cas   cas.Storage
+	repo  *repo.Repo
+
+	// tokens refreshes the OAuth tokens of the plugin's instances
+	tokens *tokens
+
+	// fixtures records extract responses or replays them instead of calling
+	// the plugin
+	fixtures *fixture.Fixtures
 }
 
-// NewDataQClient creates a new DataQClient with index-based request hash handling
+// NewDataQClient creates a new DataQClient with index-based request hash handling.
+// conn may be nil until the plugin is connected.
 func NewDataQClient(conn *grpc.ClientConn, idx *index.Index, cas cas.Storage, repo *repo.Repo) *DataQClient {
-	return &DataQClient{
-		client: rpc.NewDataQPluginClient(conn),
+	c := &DataQClient{
 		index:  idx,
 		cas:    cas,
 		repo:   repo,
+		tokens: newTokens(idx, repo),
 	}
+	c.connect(conn)
+	return c
+}
+
+// connect replaces the connection to the plugin, e.g. after it restarted on a
+// new address, and closes the previous one.
+func (c *DataQClient) connect(conn *grpc.ClientConn) {
+	c.mu.Lock()
+	prev := c.conn
+	c.conn = conn
+	c.client = nil
+	if conn != nil {
+		c.client = rpc.NewDataQPluginClient(conn)
+	}
+	c.mu.Unlock()
+
+	if prev != nil && prev != conn {
+		prev.Close()
+	}
+}
+
+// plugin returns the client of the current connection
+func (c *DataQClient) plugin() (rpc.DataQPluginClient, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.client == nil {
+		return nil, fmt.Errorf("plugin is not connected")
+	}
+	return c.client, nil
+}
+
+// Close closes the connection to the plugin
+func (c *DataQClient) Close() {
+	c.connect(nil)
 }
 
 func (c *DataQClient) Install(ctx context.Context, req *rpc.InstallRequest, opts ...grpc.CallOption) (*rpc.InstallResponse, error) {
+	client, err := c.plugin()
+	if err != nil {
+		return nil, err
+	}
+
 	// passthru for now
-	return c.client.Install(ctx, req, opts...)
+	return client.Install(ctx, req, opts...)
 }
 
 // Extract performs an extraction with index-based request hash
@@ -49,19 +109,49 @@ func (c *DataQClient) Extract(ctx context.Context, plugin *schema.PluginInstance
 		return nil, err
 	}
 
-	// always attaching here is more explicit
-	req.Oauth = plugin.Oauth
+	// Reuse the response to an identical request if it is still fresh
+	identical, err := c.identicalExtracts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	cached := new(rpc.ExtractResponse)
+	if ok, err := c.cached(ctx, cached, sq.Expr("request_hash IN (?)", identical), extractFreshness(plugin, req.Kind)); err != nil {
+		return nil, err
+	} else if ok {
+		return cached, nil
+	}
+
+	if streamed(plugin, req.Kind) {
+		return c.extractStream(ctx, plugin, req, hash, opts...)
+	}
 
-	res, err := c.client.Extract(ctx, req, opts...)
+	res, err := c.extract(ctx, plugin, req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := c.storeResponse(ctx, req, hash, res); err != nil {
+		return nil, err
+	}
+
+	// Only advance the sync state once what was extracted is stored
+	if err := c.repo.UpdateSyncState(ctx, req.InstanceHash, res.State); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// storeResponse stores the content of a response to the request at hash in the
+// CAS, and the response and the transform requests it asks for in the index.
+// The content of res is replaced with its address.
+func (c *DataQClient) storeResponse(ctx context.Context, req *rpc.ExtractRequest, hash string, res *rpc.ExtractResponse) error {
 	res.RequestHash = hash
+	res.ReceivedAt = timestamppb.Now()
 
 	content := res.GetContent()
 	if content == nil {
-		return nil, fmt.Errorf("response content is nil")
+		return fmt.Errorf("response content is nil")
 	}
 
 	r := bytes.NewReader(content)
@@ -69,7 +159,7 @@ func (c *DataQClient) Extract(ctx context.Context, plugin *schema.PluginInstance
 	// Store the response content in the CAS
 	dataHash, err := c.cas.Store(ctx, r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to store response content: %w", err)
+		return fmt.Errorf("failed to store response content: %w", err)
 	}
 
 	// replace the contents with a hash address to the content
@@ -80,7 +170,7 @@ func (c *DataQClient) Extract(ctx context.Context, plugin *schema.PluginInstance
 	// For each transform in the response, create a transform request
 	for _, transform := range res.GetTransforms() {
 		transformReq := &rpc.TransformRequest{
-			PluginId: req.PluginId,
+			InstanceHash: req.InstanceHash,
 			Data: &rpc.TransformRequest_Hash{
 				Hash: dataHash,
 			},
@@ -90,18 +180,140 @@ func (c *DataQClient) Extract(ctx context.Context, plugin *schema.PluginInstance
 
 		// Store the transform request
 		if _, err := c.index.Store(ctx, transformReq); err != nil {
-			return nil, fmt.Errorf("failed to store transform request: %w", err)
+			return fmt.Errorf("failed to store transform request: %w", err)
 		}
 	}
 
 	// Store the response in the index
 	if _, err = c.index.Store(ctx, res); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// extract calls the plugin, or replays the response recorded for req.
+func (c *DataQClient) extract(ctx context.Context, plugin *schema.PluginInstance, req *rpc.ExtractRequest, opts ...grpc.CallOption) (*rpc.ExtractResponse, error) {
+	if c.fixtures.Replaying() {
+		return c.fixtures.Load(plugin.PluginID, req)
+	}
+
+	client, err := c.plugin()
+	if err != nil {
+		return nil, err
+	}
+
+	// always attaching here is more explicit
+	if req.Oauth, err = c.tokens.oauth(ctx, req.InstanceHash); err != nil {
 		return nil, err
 	}
 
+	state, err := c.repo.SyncState(ctx, req.InstanceHash)
+	if err != nil {
+		return nil, err
+	}
+	req.State = state.Values
+
+	res, err := client.Extract(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.fixtures.Save(plugin.PluginID, req, res); err != nil {
+		return nil, fmt.Errorf("failed to record fixture: %w", err)
+	}
+
 	return res, nil
 }
 
+// extractStream calls the plugin for a streamed kind, or replays the items
+// recorded for req, and stores every item before it receives the next one. A
+// slow index slows the plugin down through gRPC flow control instead of
+// buffering items. It returns the last item, nil if there were none.
+func (c *DataQClient) extractStream(ctx context.Context, plugin *schema.PluginInstance, req *rpc.ExtractRequest, hash string, opts ...grpc.CallOption) (*rpc.ExtractResponse, error) {
+	var items iter.Seq2[*rpc.ExtractResponse, error]
+	if c.fixtures.Replaying() {
+		items = c.fixtures.LoadStream(plugin.PluginID, req)
+	} else {
+		client, err := c.plugin()
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Oauth, err = c.tokens.oauth(ctx, req.InstanceHash); err != nil {
+			return nil, err
+		}
+		state, err := c.repo.SyncState(ctx, req.InstanceHash)
+		if err != nil {
+			return nil, err
+		}
+		req.State = state.Values
+
+		// Stop the plugin if storing an item fails
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := client.ExtractStream(ctx, req, opts...)
+		if err != nil {
+			return nil, err
+		}
+		items = receive(stream)
+	}
+
+	// Items stored before the stream fails are kept, but the sync state only
+	// advances once every item is stored
+	var last *rpc.ExtractResponse
+	state := make(map[string][]byte)
+	n := 0
+	for res, err := range items {
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive item %d: %w", n, err)
+		}
+
+		if err := c.fixtures.SaveItem(plugin.PluginID, req, n, res); err != nil {
+			return nil, fmt.Errorf("failed to record fixture: %w", err)
+		}
+		if err := c.storeResponse(ctx, req, hash, res); err != nil {
+			return nil, fmt.Errorf("failed to store item %d: %w", n, err)
+		}
+
+		maps.Copy(state, res.State)
+		last = res
+		n++
+	}
+
+	if err := c.repo.UpdateSyncState(ctx, req.InstanceHash, state); err != nil {
+		return nil, err
+	}
+
+	return last, nil
+}
+
+// receive yields the items of a stream until it ends.
+func receive(stream grpc.ServerStreamingClient[rpc.ExtractResponse]) iter.Seq2[*rpc.ExtractResponse, error] {
+	return func(yield func(*rpc.ExtractResponse, error) bool) {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if !yield(res, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamed reports whether the plugin declared an extract kind as streamed.
+func streamed(plugin *schema.PluginInstance, kind string) bool {
+	for _, e := range plugin.InstallResponse.GetExtracts() {
+		if e.GetKind() == kind {
+			return e.GetStream()
+		}
+	}
+	return false
+}
+
 // Transform performs a transformation with index-based request hash
 func (c *DataQClient) Transform(ctx context.Context, req *rpc.TransformRequest, opts ...grpc.CallOption) (*rpc.TransformResponse, error) {
 	// Store the request in the index to get a hash
@@ -112,6 +324,19 @@ func (c *DataQClient) Transform(ctx context.Context, req *rpc.TransformRequest,
 		return nil, err
 	}
 
+	plugin, err := c.repo.GetPluginInstance(ctx, req.InstanceHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse the response to an identical request if it is still fresh
+	cached := new(rpc.TransformResponse)
+	if ok, err := c.cached(ctx, cached, sq.Eq{"request_hash": hash}, transformFreshness(plugin, req.Kind)); err != nil {
+		return nil, err
+	} else if ok {
+		return cached, nil
+	}
+
 	// If request contains a hash, fetch the content from CAS
 	if reqHash := req.GetHash(); reqHash != "" {
 		r, err := c.cas.Retrieve(ctx, reqHash)
@@ -128,29 +353,44 @@ func (c *DataQClient) Transform(ctx context.Context, req *rpc.TransformRequest,
 		req.Data = &rpc.TransformRequest_Content{
 			Content: content,
 		}
+		req.DataHash = reqHash
 	} else {
 		return nil, fmt.Errorf("request hash is empty")
 	}
 
-	res, err := c.client.Transform(ctx, req, opts...)
+	client, err := c.plugin()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Transform(ctx, req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	res.RequestHash = hash
+	res.ReceivedAt = timestamppb.Now()
+
+	// Reject the whole response before anything is stored if a permanode is
+	// malformed, claims can't be changed later
+	permanodes, err := c.permanodes(ctx, req.InstanceHash, res)
+	if err != nil {
+		return nil, err
+	}
 
 	// Store the response in the index
-	if _, err = c.index.Store(ctx, res); err != nil {
+	resHash, err := c.index.Store(ctx, res)
+	if err != nil {
 		return nil, err
 	}
 
 	// For each extract in the response, create an extract request
 	for _, extract := range res.GetExtracts() {
 		extractReq := &rpc.ExtractRequest{
-			PluginId:   req.PluginId,
-			ParentHash: hash,
-			Kind:       extract.Kind,
-			Metadata:   extract.Metadata,
+			InstanceHash: req.InstanceHash,
+			ParentHash:   hash,
+			Kind:         extract.Kind,
+			Metadata:     extract.Metadata,
 		}
 
 		// Store the extract request
@@ -160,22 +400,156 @@ func (c *DataQClient) Transform(ctx context.Context, req *rpc.TransformRequest,
 	}
 
 	// For each permanode in the response, store the permanode version
-	for _, permanode := range res.GetPermanodes() {
-		// Handle the different payload types
-		var content index.Indexable
-		switch p := permanode.Payload.(type) {
-		case *rpc.TransformResponse_Permanode_Email:
-			content = p.Email
-		case *rpc.TransformResponse_Permanode_FinancialTransaction:
-			content = p.FinancialTransaction
-		default:
-			return nil, fmt.Errorf("unknown payload type: %T", p)
-		}
-
-		if _, err := c.index.CreateDataSource(ctx, req.PluginId, permanode.Key, content); err != nil {
+	for _, p := range permanodes {
+		if _, err := c.index.CreateDataSource(ctx, req.InstanceHash, p.key, resHash, p.content); err != nil {
 			return nil, fmt.Errorf("failed to create data source: %w", err)
 		}
 	}
 
 	return res, nil
 }
+
+// identicalExtracts selects the hashes of the extract requests identical to req.
+// Like fixtures, requests match on their instance, kind and metadata: the
+// parent differs when different transforms spawn the same extract.
+func (c *DataQClient) identicalExtracts(ctx context.Context, req *rpc.ExtractRequest) (sq.SelectBuilder, error) {
+	sel := sq.Select("content_hash").
+		From("index_data").
+		Where(sq.Eq{"schema_kind": req.SchemaKind()}).
+		Where(sq.Eq{"instance_hash": req.InstanceHash}).
+		Where(sq.Eq{"kind": req.Kind})
+
+	if len(req.Metadata) > 0 {
+		// Indexed as JSON, with sorted keys
+		b, err := json.Marshal(req.Metadata)
+		if err != nil {
+			return sel, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		return sel.Where(sq.Eq{"metadata": string(b)}), nil
+	}
+
+	columns, err := c.index.Columns(ctx)
+	if err != nil {
+		return sel, fmt.Errorf("failed to get columns: %w", err)
+	}
+	if slices.Contains(columns, "metadata") {
+		sel = sel.Where(sq.Or{sq.Eq{"metadata": nil}, sq.Eq{"metadata": "{}"}})
+	}
+	return sel, nil
+}
+
+// cached reads the latest response matching request into result, unless
+// freshness requires calling the plugin again. It reports whether there was a
+// response to reuse.
+func (c *DataQClient) cached(ctx context.Context, result index.Indexable, request sq.Sqlizer, freshness *rpc.Freshness) (bool, error) {
+	if freshness.GetPolicy() == rpc.Freshness_ALWAYS {
+		return false, nil
+	}
+
+	claims, err := c.index.Query(ctx, c.index.Q.
+		Where(sq.Eq{"schema_kind": result.SchemaKind()}).
+		Where(request).
+		OrderBy("received_at DESC").
+		Limit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to query responses: %w", err)
+	}
+	if len(claims) == 0 {
+		return false, nil
+	}
+
+	if freshness.GetPolicy() == rpc.Freshness_TTL {
+		receivedAt, _ := claims[0].Metadata["received_at"].(int64)
+		if time.Since(time.UnixMilli(receivedAt)) > freshness.GetTtl().AsDuration() {
+			return false, nil
+		}
+	}
+
+	if err := c.repo.GetContent(ctx, claims[0].ContentHash, result); err != nil {
+		return false, fmt.Errorf("failed to get response: %w", err)
+	}
+
+	return true, nil
+}
+
+// extractFreshness returns the freshness the plugin declared for an extract kind.
+func extractFreshness(plugin *schema.PluginInstance, kind string) *rpc.Freshness {
+	for _, e := range plugin.InstallResponse.GetExtracts() {
+		if e.GetKind() == kind {
+			return e.GetFreshness()
+		}
+	}
+	return nil
+}
+
+// transformFreshness returns the freshness the plugin declared for a transform kind.
+func transformFreshness(plugin *schema.PluginInstance, kind string) *rpc.Freshness {
+	for _, t := range plugin.InstallResponse.GetTransforms() {
+		if t.GetKind() == kind {
+			return t.GetFreshness()
+		}
+	}
+	return nil
+}
+
+type permanodeContent struct {
+	key     string
+	content index.Indexable
+}
+
+// permanodes returns the validated content of the permanodes in a transform
+// response.
+func (c *DataQClient) permanodes(ctx context.Context, instance string, res *rpc.TransformResponse) ([]permanodeContent, error) {
+	var permanodes []permanodeContent
+	descriptorsLoaded := false
+	for _, permanode := range res.GetPermanodes() {
+		// Types that aren't compiled in are described by the plugin
+		if _, ok := permanode.Payload.(*rpc.TransformResponse_Permanode_Any); ok && !descriptorsLoaded {
+			if err := c.storeDescriptors(ctx, instance); err != nil {
+				return nil, err
+			}
+			descriptorsLoaded = true
+		}
+
+		content, err := index.PermanodeContent(permanode)
+		if err != nil {
+			return nil, err
+		}
+
+		// Kinds with unique_key fields don't need the plugin to set a key
+		key := permanode.Key
+		if keyed, ok := content.(index.Keyed); ok && key == "" {
+			key = keyed.SchemaKey()
+		}
+
+		if v, ok := content.(index.Validator); ok {
+			if err := v.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid %s permanode %q: %w", content.SchemaKind(), key, err)
+			}
+		}
+
+		permanodes = append(permanodes, permanodeContent{key: key, content: content})
+	}
+
+	return permanodes, nil
+}
+
+// storeDescriptors stores the descriptor set a plugin instance shipped in its
+// install response, so its Any payloads can be indexed.
+func (c *DataQClient) storeDescriptors(ctx context.Context, instance string) error {
+	plugin, err := c.repo.GetPluginInstance(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	descriptors := plugin.InstallResponse.GetDescriptors()
+	if descriptors == nil {
+		return nil
+	}
+
+	if _, err := c.index.StoreDescriptors(ctx, descriptors); err != nil {
+		return fmt.Errorf("failed to store plugin descriptors: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,351 @@
+package boot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.quinn.io/dataq/canonical"
+	"go.quinn.io/dataq/cas"
+	"go.quinn.io/dataq/config"
+	"go.quinn.io/dataq/handshake"
+	"go.quinn.io/dataq/index"
+	"go.quinn.io/dataq/internal/repo"
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+)
+
+const (
+	// defaultQueryLimit is the number of permanodes QueryOwnPermanodes returns
+	// when the request has no limit
+	defaultQueryLimit = 100
+
+	// maxQueryLimit is the most permanodes QueryOwnPermanodes returns
+	maxQueryLimit = 1000
+)
+
+// hostServer serves DataQHost to the processes of a plugin. Requests name a
+// plugin instance, which must be an instance of that plugin, and only reach the
+// data of that instance.
+type hostServer struct {
+	rpc.UnimplementedDataQHostServer
+
+	pluginID string
+	index    *index.Index
+	cas      cas.Storage
+	repo     *repo.Repo
+}
+
+// serveHost serves DataQHost to plugin cfg, on a Unix socket in its state
+// directory where supported. It returns the server and the environment the
+// plugin is started with to reach it.
+func (pm *PluginManager) serveHost(cfg *config.Plugin, stateDir string) (*grpc.Server, []string, error) {
+	var lis net.Listener
+	var err error
+	socket := filepath.Join(stateDir, "host.sock")
+	if runtime.GOOS == "windows" || len(socket) > maxSocketPath {
+		lis, err = net.Listen("tcp", "127.0.0.1:0")
+	} else {
+		// A previous host may have left the socket behind
+		if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("failed to remove stale host socket: %w", err)
+		}
+		lis, err = net.Listen("unix", socket)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen for plugin %s: %w", cfg.ID, err)
+	}
+
+	token, err := handshake.NewToken()
+	if err != nil {
+		lis.Close()
+		return nil, nil, err
+	}
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(handshake.Authorize(token)))
+	rpc.RegisterDataQHostServer(s, &hostServer{
+		pluginID: cfg.ID,
+		index:    pm.index,
+		cas:      pm.cas,
+		repo:     pm.repo,
+	})
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Printf("Host service of plugin %s stopped: %v", cfg.ID, err)
+		}
+	}()
+
+	return s, handshake.HostEnv(lis, token), nil
+}
+
+// authorize checks that instance is an instance of the plugin.
+func (h *hostServer) authorize(ctx context.Context, instance string) error {
+	if instance == "" {
+		return status.Error(codes.InvalidArgument, "instance_hash is required")
+	}
+
+	plugin, err := h.repo.GetPluginInstance(ctx, instance)
+	if err != nil || plugin.PluginID != h.pluginID {
+		return status.Errorf(codes.PermissionDenied, "%s is not an instance of plugin %s", instance, h.pluginID)
+	}
+
+	return nil
+}
+
+// dataSources selects the permanodes an instance manages
+func dataSources(instance string) sq.SelectBuilder {
+	return sq.Select("permanode_hash").
+		From("index_data").
+		Where(sq.Eq{"schema_kind": "DataSource"}).
+		Where(sq.Eq{"plugin_id": instance})
+}
+
+// GetBlob returns a blob the instance extracted, stored or manages as the
+// content of a permanode.
+func (h *hostServer) GetBlob(ctx context.Context, req *rpc.GetBlobRequest) (*rpc.GetBlobResponse, error) {
+	if err := h.authorize(ctx, req.InstanceHash); err != nil {
+		return nil, err
+	}
+
+	owned, err := h.ownsBlob(ctx, req.InstanceHash, req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, status.Errorf(codes.NotFound, "blob %s not found", req.Hash)
+	}
+
+	r, err := h.cas.Retrieve(ctx, req.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve blob: %w", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	return &rpc.GetBlobResponse{Content: content}, nil
+}
+
+// ownsBlob reports whether the instance may read the blob at hash.
+func (h *hostServer) ownsBlob(ctx context.Context, instance, hash string) (bool, error) {
+	if hash == "" {
+		return false, nil
+	}
+
+	columns, err := h.index.Columns(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get columns: %w", err)
+	}
+	byInstance := sq.Or{sq.Expr("1 = 0")}
+	if slices.Contains(columns, "instance_hash") {
+		byInstance = append(byInstance, sq.Eq{"instance_hash": instance})
+	}
+	// Requests indexed before the instance_hash rename only have plugin_id
+	if slices.Contains(columns, "plugin_id") {
+		legacy := sq.And{sq.Eq{"plugin_id": instance}}
+		if slices.Contains(columns, "instance_hash") {
+			legacy = append(legacy, sq.Eq{"instance_hash": nil})
+		}
+		byInstance = append(byInstance, legacy)
+	}
+	requests := sq.Select("content_hash").
+		From("index_data").
+		Where(sq.Eq{"schema_kind": "ExtractRequest"}).
+		Where(byInstance)
+
+	queries := []sq.SelectBuilder{
+		// Extracted content
+		h.index.Q.
+			Where(sq.Eq{"schema_kind": "ExtractResponse"}).
+			Where(sq.Eq{"data_hash": hash}).
+			Where(sq.Expr("request_hash IN (?)", requests)),
+		// Content of its permanodes
+		h.index.Q.
+			Where(sq.Eq{"content_hash": hash}).
+			Where(sq.Expr("permanode_hash IN (?)", dataSources(instance))),
+		// Stored with PutBlob
+		h.index.Q.
+			Where(sq.Eq{"schema_kind": "PluginBlob"}).
+			Where(sq.Eq{"plugin_id": instance}).
+			Where(sq.Eq{"blob_hash": hash}),
+	}
+	for _, query := range queries {
+		claims, err := h.index.Query(ctx, query.Limit(1))
+		if err != nil {
+			return false, fmt.Errorf("failed to query index: %w", err)
+		}
+		if len(claims) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// PutBlob stores a blob and records that the instance may read it.
+func (h *hostServer) PutBlob(ctx context.Context, req *rpc.PutBlobRequest) (*rpc.PutBlobResponse, error) {
+	if err := h.authorize(ctx, req.InstanceHash); err != nil {
+		return nil, err
+	}
+
+	hash, err := h.cas.Store(ctx, bytes.NewReader(req.Content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	if _, err := h.index.Store(ctx, &schema.PluginBlob{PluginID: req.InstanceHash, Hash: hash}); err != nil {
+		return nil, fmt.Errorf("failed to index blob: %w", err)
+	}
+
+	return &rpc.PutBlobResponse{Hash: hash}, nil
+}
+
+// QueryOwnPermanodes returns the latest content of the permanodes the instance
+// manages, most recently updated first.
+func (h *hostServer) QueryOwnPermanodes(ctx context.Context, req *rpc.QueryOwnPermanodesRequest) (*rpc.QueryOwnPermanodesResponse, error) {
+	if err := h.authorize(ctx, req.InstanceHash); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	switch {
+	case limit <= 0:
+		limit = defaultQueryLimit
+	case limit > maxQueryLimit:
+		limit = maxQueryLimit
+	}
+
+	sources := dataSources(req.InstanceHash)
+	if req.Key != "" {
+		sources = sources.Where(sq.Eq{"plugin_key": req.Key})
+	}
+
+	// Only the latest version of each permanode
+	sel := h.index.Q.
+		Where(sq.Expr("permanode_hash IN (?)", sources)).
+		Where(sq.NotEq{"content_hash": ""}).
+		Where("timestamp = (SELECT MAX(v.timestamp) FROM index_data v WHERE v.permanode_hash = index_data.permanode_hash AND v.content_hash != '')").
+		OrderBy("timestamp DESC").
+		Limit(uint64(limit))
+	if req.Kind != "" {
+		sel = sel.Where(sq.Eq{"schema_kind": req.Kind})
+	}
+
+	if len(req.Filters) > 0 {
+		columns, err := h.index.Columns(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get columns: %w", err)
+		}
+		for column, value := range req.Filters {
+			if !slices.Contains(columns, column) {
+				return nil, status.Errorf(codes.InvalidArgument, "unknown column %q", column)
+			}
+			sel = sel.Where(sq.Eq{index.QuoteIdent(column): value})
+		}
+	}
+
+	claims, err := h.index.Query(ctx, sel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query permanodes: %w", err)
+	}
+
+	keys, err := h.permanodeKeys(ctx, req.InstanceHash, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &rpc.QueryOwnPermanodesResponse{}
+	for _, claim := range claims {
+		content, err := h.index.UnmarshalContent(ctx, claim, claim.ContentHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get permanode %s: %w", claim.PermanodeHash, err)
+		}
+		b, err := canonical.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal permanode %s: %w", claim.PermanodeHash, err)
+		}
+
+		res.Permanodes = append(res.Permanodes, &rpc.QueryOwnPermanodesResponse_Permanode{
+			Hash:      claim.PermanodeHash,
+			Key:       keys[claim.PermanodeHash],
+			Kind:      claim.SchemaKind,
+			Content:   b,
+			UpdatedAt: timestamppb.New(claim.Timestamp),
+		})
+	}
+
+	return res, nil
+}
+
+// permanodeKeys returns the plugin key of each permanode of claims.
+func (h *hostServer) permanodeKeys(ctx context.Context, instance string, claims []schema.Claim) (map[string]string, error) {
+	keys := make(map[string]string, len(claims))
+	if len(claims) == 0 {
+		return keys, nil
+	}
+
+	hashes := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		hashes = append(hashes, claim.PermanodeHash)
+	}
+
+	sources, err := h.index.Query(ctx, h.index.Q.
+		Where(sq.Eq{"schema_kind": "DataSource"}).
+		Where(sq.Eq{"plugin_id": instance}).
+		Where(sq.Eq{"permanode_hash": hashes}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data sources: %w", err)
+	}
+	for _, source := range sources {
+		keys[source.PermanodeHash], _ = source.Metadata["plugin_key"].(string)
+	}
+
+	return keys, nil
+}
+
+// GetPluginState returns the value of a key of the instance's sync state.
+func (h *hostServer) GetPluginState(ctx context.Context, req *rpc.GetPluginStateRequest) (*rpc.GetPluginStateResponse, error) {
+	if err := h.authorize(ctx, req.InstanceHash); err != nil {
+		return nil, err
+	}
+
+	state, err := h.repo.SyncState(ctx, req.InstanceHash)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := state.Values[req.Key]
+	return &rpc.GetPluginStateResponse{Value: value, Found: ok}, nil
+}
+
+// SetPluginState sets a key of the instance's sync state.
+func (h *hostServer) SetPluginState(ctx context.Context, req *rpc.SetPluginStateRequest) (*rpc.SetPluginStateResponse, error) {
+	if err := h.authorize(ctx, req.InstanceHash); err != nil {
+		return nil, err
+	}
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	if err := h.repo.UpdateSyncState(ctx, req.InstanceHash, map[string][]byte{req.Key: req.Value}); err != nil {
+		return nil, err
+	}
+
+	return &rpc.SetPluginStateResponse{}, nil
+}
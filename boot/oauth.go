@@ -0,0 +1,98 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/proto"
+
+	"go.quinn.io/dataq/index"
+	"go.quinn.io/dataq/internal/repo"
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+)
+
+// tokens refreshes the OAuth tokens of plugin instances and stores the
+// refreshed tokens in their PluginInstance permanode. Plugins only get the
+// access token, so the host is the only one to refresh it.
+type tokens struct {
+	index *index.Index
+	repo  *repo.Repo
+
+	mu sync.Mutex
+	// instances holds a lock per instance, so a token is refreshed once
+	instances map[string]*sync.Mutex
+}
+
+func newTokens(idx *index.Index, repo *repo.Repo) *tokens {
+	return &tokens{
+		index:     idx,
+		repo:      repo,
+		instances: make(map[string]*sync.Mutex),
+	}
+}
+
+// lock locks the token of instance and returns the function unlocking it.
+func (t *tokens) lock(instance string) func() {
+	t.mu.Lock()
+	mu, ok := t.instances[instance]
+	if !ok {
+		mu = new(sync.Mutex)
+		t.instances[instance] = mu
+	}
+	t.mu.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
+
+// oauth returns the OAuth config of instance with a token that hasn't expired,
+// refreshed if needed, and without its refresh token. It is nil if the
+// instance has no OAuth config.
+func (t *tokens) oauth(ctx context.Context, instance string) (*rpc.OAuth2, error) {
+	unlock := t.lock(instance)
+	defer unlock()
+
+	// Read the instance under the lock, it may have just been refreshed
+	plugin, err := t.repo.GetPluginInstance(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	if plugin.Oauth.GetConfig() == nil {
+		return nil, nil
+	}
+	if plugin.Oauth.GetToken() == nil {
+		return nil, fmt.Errorf("plugin %s is not connected to OAuth", plugin.Label)
+	}
+	if plugin.OauthRevoked {
+		return nil, fmt.Errorf("OAuth access of plugin %s was revoked, connect it again", plugin.Label)
+	}
+
+	prev := schema.NewOauthToken(plugin.Oauth)
+	token, err := schema.NewOauthConfig(plugin.Oauth).TokenSource(ctx, prev).Token()
+	if err != nil {
+		var retrieve *oauth2.RetrieveError
+		if errors.As(err, &retrieve) && retrieve.ErrorCode == "invalid_grant" {
+			plugin.OauthRevoked = true
+			if _, err := t.index.UpdatePermanode(ctx, instance, plugin); err != nil {
+				return nil, fmt.Errorf("failed to flag revoked token: %w", err)
+			}
+			return nil, fmt.Errorf("OAuth access of plugin %s was revoked, connect it again: %w", plugin.Label, err)
+		}
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	if token.AccessToken != prev.AccessToken {
+		plugin.Oauth.Token = schema.NewRPCOauthToken(token)
+		if _, err := t.index.UpdatePermanode(ctx, instance, plugin); err != nil {
+			return nil, fmt.Errorf("failed to store refreshed token: %w", err)
+		}
+	}
+
+	oauth := proto.Clone(plugin.Oauth).(*rpc.OAuth2)
+	oauth.Token.RefreshToken = ""
+	return oauth, nil
+}
@@ -1,43 +1,52 @@
 package boot
 
 import (
+	"bufio"
 	"context"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"sync"
-	"syscall"
+	"time"
 
 	"go.quinn.io/dataq/cas"
 	"go.quinn.io/dataq/config"
+	"go.quinn.io/dataq/fixture"
+	"go.quinn.io/dataq/handshake"
 	"go.quinn.io/dataq/index"
 	"go.quinn.io/dataq/internal/repo"
+	"go.quinn.io/dataq/schema"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-// PluginManager manages plugin processes and their gRPC clients
+// PluginManager manages plugin processes and their gRPC clients. There is a
+// process per plugin, shared by all its instances.
 type PluginManager struct {
 	sync.RWMutex
-	Clients   map[string]*DataQClient
-	processes map[string]*exec.Cmd
-	index     *index.Index
-	cas       cas.Storage
-	repo      *repo.Repo
-	basePort  int
+	Clients     map[string]*DataQClient
+	supervisors map[string]*supervisor
+	hosts       map[string]*grpc.Server
+	index       *index.Index
+	cas         cas.Storage
+	repo        *repo.Repo
+	fixtures    *fixture.Fixtures
 }
 
-// NewPluginManager creates a new plugin manager
-func NewPluginManager(idx *index.Index, cas cas.Storage, repo *repo.Repo) *PluginManager {
+// NewPluginManager creates a new plugin manager. Extracts are recorded or
+// replayed with fixtures unless it is nil.
+func NewPluginManager(idx *index.Index, cas cas.Storage, repo *repo.Repo, fixtures *fixture.Fixtures) *PluginManager {
 	return &PluginManager{
-		Clients:   make(map[string]*DataQClient),
-		processes: make(map[string]*exec.Cmd),
-		index:     idx,
-		cas:       cas,
-		repo:      repo,
-		basePort:  50051,
+		Clients:     make(map[string]*DataQClient),
+		supervisors: make(map[string]*supervisor),
+		hosts:       make(map[string]*grpc.Server),
+		index:       idx,
+		cas:         cas,
+		repo:        repo,
+		fixtures:    fixtures,
 	}
 }
 
@@ -52,52 +61,128 @@ func (pm *PluginManager) GetClient(pluginID string) (*DataQClient, error) {
 	}
 	return client, nil
 }
-func (pm *PluginManager) startPlugin(cfg *config.Plugin) (*exec.Cmd, *grpc.ClientConn, error) {
-	port := fmt.Sprintf("%d", pm.basePort)
 
-	// Create plugin state directory if it doesn't exist
-	pluginStateDir := filepath.Join(config.StateDir(), cfg.ID)
-	if err := os.MkdirAll(pluginStateDir, 0755); err != nil {
-		return nil, nil, fmt.Errorf("failed to create plugin state directory: %w", err)
+// Instance returns a plugin instance and the client of the plugin it is an
+// instance of. Instances of a plugin share its process.
+func (pm *PluginManager) Instance(ctx context.Context, hash string) (*DataQClient, *schema.PluginInstance, error) {
+	instance, err := pm.repo.GetPluginInstance(ctx, hash)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Start the plugin process
-	cmd := exec.Command(filepath.Join(config.StateDir(), "bin", cfg.BinaryPath))
-	cmd.Dir = pluginStateDir
+	client, err := pm.GetClient(instance.PluginID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// configJSON, err := json.Marshal(map[string]interface{}{
-	// 	"oauth_config": plugin.OauthConfig,
-	// 	"oauth_token":  plugin.OauthToken,
-	// 	"config":       plugin.Config,
-	// })
+	return client, instance, nil
+}
 
-	cmd.Env = append(os.Environ(),
-		fmt.Sprintf("PORT=%s", port),
-		// fmt.Sprintf("CONFIG=%s", string(configJSON)),
-	)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// Status returns the state of every plugin process, ordered by plugin ID
+func (pm *PluginManager) Status() []PluginStatus {
+	pm.RLock()
+	defer pm.RUnlock()
 
-	if err := cmd.Start(); err != nil {
-		return nil, nil, fmt.Errorf("failed to start plugin process: %w", err)
+	statuses := make([]PluginStatus, 0, len(pm.supervisors))
+	for _, s := range pm.supervisors {
+		statuses = append(statuses, s.Status())
 	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
 
-	// Connect to the plugin
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("localhost:%s", port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		cmd.Process.Kill()
-		return nil, nil, fmt.Errorf("failed to connect to plugin: %w", err)
+	return statuses
+}
+
+// handshakeTimeout is how long a plugin has to announce its address and pass
+// a health check after it is started
+const handshakeTimeout = 10 * time.Second
+
+// maxSocketPath is the longest Unix socket path that works on every platform
+const maxSocketPath = 100
+
+// startPlugin starts the plugin process under a supervisor. Every time the
+// process starts, the client is connected to the address it announces in its
+// handshake. The plugin reaches the host service at the address in hostEnv.
+func (pm *PluginManager) startPlugin(cfg *config.Plugin, client *DataQClient, hostEnv []string) (*supervisor, error) {
+	pluginStateDir := filepath.Join(config.StateDir(), cfg.ID)
+
+	// Fall back to TCP where Unix sockets aren't available
+	socket := filepath.Join(pluginStateDir, "plugin.sock")
+	if runtime.GOOS == "windows" || len(socket) > maxSocketPath {
+		socket = ""
+	}
+
+	stderr := newRingBuffer(stderrSize)
+	start := func() (*exec.Cmd, error) {
+		// Start the plugin process
+		cmd := exec.Command(filepath.Join(config.StateDir(), "bin", cfg.BinaryPath))
+		cmd.Dir = pluginStateDir
+
+		// configJSON, err := json.Marshal(map[string]interface{}{
+		// 	"oauth_config": plugin.OauthConfig,
+		// 	"oauth_token":  plugin.OauthToken,
+		// 	"config":       plugin.Config,
+		// })
+
+		cmd.Env = append(os.Environ(), handshake.Env(socket)...)
+		cmd.Env = append(cmd.Env, hostEnv...)
+		cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get plugin stdout: %w", err)
+		}
+
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start plugin process: %w", err)
+		}
+
+		conn, err := connectPlugin(stdout)
+		if err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return nil, fmt.Errorf("plugin %s: %w", cfg.ID, err)
+		}
+
+		client.connect(conn)
+		return cmd, nil
 	}
 
+	return supervise(cfg, stderr, start)
+}
+
+// connectPlugin reads the handshake from a plugin's stdout and connects to
+// the address it announces. The rest of stdout is copied to the host's.
+func connectPlugin(stdout io.Reader) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+
+	type result struct {
+		addr handshake.Address
+		err  error
+	}
+	read := make(chan result, 1)
+	r := bufio.NewReader(stdout)
 	go func() {
-		cmd.Wait()
-		panic("plugin process exited")
+		addr, err := handshake.Read(r, os.Stdout)
+		read <- result{addr, err}
+		if err == nil {
+			io.Copy(os.Stdout, r)
+		}
 	}()
-	pm.basePort++
-	return cmd, conn, nil
+
+	var addr handshake.Address
+	select {
+	case res := <-read:
+		if res.err != nil {
+			return nil, res.err
+		}
+		addr = res.addr
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no handshake after %s", handshakeTimeout)
+	}
+
+	return handshake.Dial(ctx, addr)
 }
 
 // AddPlugin adds a new plugin process and client
@@ -105,67 +190,64 @@ func (pm *PluginManager) AddPlugin(cfg *config.Plugin) error {
 	pm.Lock()
 	defer pm.Unlock()
 
-	process, conn, err := pm.startPlugin(cfg)
+	// Create plugin state directory if it doesn't exist
+	pluginStateDir := filepath.Join(config.StateDir(), cfg.ID)
+	if err := os.MkdirAll(pluginStateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create plugin state directory: %w", err)
+	}
+
+	host, hostEnv, err := pm.serveHost(cfg, pluginStateDir)
+	if err != nil {
+		return fmt.Errorf("failed to serve host to plugin %s: %w", cfg.ID, err)
+	}
+
+	client := NewDataQClient(nil, pm.index, pm.cas, pm.repo)
+	client.fixtures = pm.fixtures
+	sup, err := pm.startPlugin(cfg, client, hostEnv)
 	if err != nil {
+		host.Stop()
 		return fmt.Errorf("failed to start plugin %s: %w", cfg.ID, err)
 	}
 
-	pm.Clients[cfg.ID] = NewDataQClient(conn, pm.index, pm.cas, pm.repo)
-	pm.processes[cfg.ID] = process
+	pm.Clients[cfg.ID] = client
+	pm.supervisors[cfg.ID] = sup
+	pm.hosts[cfg.ID] = host
 
 	return nil
 }
 
-// Shutdown gracefully stops all plugin processes
+// Shutdown gracefully stops all plugin processes. Processes that haven't
+// exited when ctx is done are killed.
 func (pm *PluginManager) Shutdown(ctx context.Context) error {
 	pm.Lock()
 	defer pm.Unlock()
 
 	var wg sync.WaitGroup
-	errChan := make(chan error, len(pm.processes))
+	errChan := make(chan error, len(pm.supervisors))
 
-	for id, proc := range pm.processes {
-		if proc == nil || proc.Process == nil {
-			continue
+	for id, sup := range pm.supervisors {
+		if client := pm.Clients[id]; client != nil {
+			client.Close()
 		}
 
 		wg.Add(1)
-		go func(id string, proc *exec.Cmd) {
+		go func(sup *supervisor) {
 			defer wg.Done()
-
-			// First try SIGTERM
-			if err := proc.Process.Signal(syscall.SIGTERM); err != nil {
-				log.Printf("Failed to send SIGTERM to plugin %s: %v", id, err)
-				if err := proc.Process.Kill(); err != nil {
-					errChan <- fmt.Errorf("failed to kill plugin %s: %w", id, err)
-				}
-				return
-			}
-
-			// Wait for process to exit with timeout
-			done := make(chan error, 1)
-			go func() {
-				done <- proc.Wait()
-			}()
-
-			select {
-			case err := <-done:
-				if err != nil {
-					errChan <- fmt.Errorf("plugin %s exit error: %w", id, err)
-				}
-			case <-ctx.Done():
-				// Context timeout - force kill
-				if err := proc.Process.Kill(); err != nil {
-					errChan <- fmt.Errorf("failed to kill plugin %s after timeout: %w", id, err)
-				}
+			if err := sup.shutdown(ctx.Done()); err != nil {
+				errChan <- err
 			}
-		}(id, proc)
+		}(sup)
 	}
 
 	// Wait for all processes
 	wg.Wait()
 	close(errChan)
 
+	// Plugins may call the host until they exit
+	for _, host := range pm.hosts {
+		host.Stop()
+	}
+
 	// Collect errors
 	var errs []error
 	for err := range errChan {
@@ -0,0 +1,292 @@
+package boot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.quinn.io/dataq/config"
+)
+
+// PluginState is the lifecycle state of a plugin process
+type PluginState string
+
+const (
+	PluginRunning    PluginState = "running"
+	PluginRestarting PluginState = "restarting"
+	PluginUnhealthy  PluginState = "unhealthy"
+	PluginStopped    PluginState = "stopped"
+)
+
+const (
+	// minBackoff is the delay before the first restart of a crashed plugin,
+	// doubled for every consecutive crash up to maxBackoff
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+
+	// maxFailures is the number of consecutive crashes after which a plugin
+	// is marked unhealthy and no longer restarted
+	maxFailures = 5
+
+	// stableAfter is how long a plugin has to run before a crash no longer
+	// counts as consecutive
+	stableAfter = 5 * time.Minute
+
+	// stderrSize is the number of bytes of stderr kept for each plugin
+	stderrSize = 16 << 10
+)
+
+// PluginStatus is a snapshot of the state of a supervised plugin
+type PluginStatus struct {
+	ID        string
+	State     PluginState
+	PID       int
+	StartedAt time.Time
+	Restarts  int
+
+	// LastError is why the process last exited
+	LastError string
+	LastExit  time.Time
+
+	// Stderr is the tail of the plugin's stderr
+	Stderr string
+}
+
+// supervisor runs a plugin process and restarts it with exponential backoff
+// when it exits unexpectedly.
+type supervisor struct {
+	cfg   *config.Plugin
+	start func() (*exec.Cmd, error)
+
+	stderr *ringBuffer
+
+	mu        sync.Mutex
+	cmd       *exec.Cmd
+	state     PluginState
+	startedAt time.Time
+	restarts  int
+	lastErr   error
+	lastExit  time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// supervise starts the plugin process with start and keeps it running until
+// shutdown is called.
+func supervise(cfg *config.Plugin, stderr *ringBuffer, start func() (*exec.Cmd, error)) (*supervisor, error) {
+	s := &supervisor{
+		cfg:    cfg,
+		start:  start,
+		stderr: stderr,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	cmd, err := start()
+	if err != nil {
+		return nil, err
+	}
+	s.running(cmd)
+
+	go s.run(cmd)
+	return s, nil
+}
+
+func (s *supervisor) running(cmd *exec.Cmd) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cmd = cmd
+	s.state = PluginRunning
+	s.startedAt = time.Now()
+}
+
+func (s *supervisor) run(cmd *exec.Cmd) {
+	defer close(s.done)
+
+	backoff := minBackoff
+	failures := 0
+	for {
+		err := cmd.Wait()
+		if err == nil {
+			err = errors.New("exited")
+		}
+
+		s.mu.Lock()
+		ranFor := time.Since(s.startedAt)
+		s.cmd = nil
+		s.lastErr = err
+		s.lastExit = time.Now()
+		s.mu.Unlock()
+
+		select {
+		case <-s.stop:
+			s.setState(PluginStopped)
+			return
+		default:
+		}
+
+		if ranFor > stableAfter {
+			failures = 0
+			backoff = minBackoff
+		}
+
+		// Restart until the plugin has crashed too often in a row
+		for cmd = nil; cmd == nil; {
+			failures++
+			if failures >= maxFailures {
+				log.Printf("Plugin %s is unhealthy after %d failures: %v", s.cfg.ID, failures, s.lastError())
+				s.setState(PluginUnhealthy)
+				<-s.stop
+				s.setState(PluginStopped)
+				return
+			}
+
+			log.Printf("Plugin %s exited (%v), restarting in %s", s.cfg.ID, s.lastError(), backoff)
+			s.setState(PluginRestarting)
+
+			select {
+			case <-s.stop:
+				s.setState(PluginStopped)
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxBackoff)
+
+			var err error
+			if cmd, err = s.start(); err != nil {
+				s.mu.Lock()
+				s.lastErr = err
+				s.lastExit = time.Now()
+				s.mu.Unlock()
+				continue
+			}
+
+			// Shutdown may have missed the new process
+			select {
+			case <-s.stop:
+				cmd.Process.Kill()
+				cmd.Wait()
+				s.setState(PluginStopped)
+				return
+			default:
+			}
+
+			s.mu.Lock()
+			s.restarts++
+			s.mu.Unlock()
+			s.running(cmd)
+		}
+	}
+}
+
+func (s *supervisor) setState(state PluginState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = state
+}
+
+func (s *supervisor) lastError() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastErr
+}
+
+// Status returns the current state of the plugin.
+func (s *supervisor) Status() PluginStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := PluginStatus{
+		ID:        s.cfg.ID,
+		State:     s.state,
+		StartedAt: s.startedAt,
+		Restarts:  s.restarts,
+		LastExit:  s.lastExit,
+		Stderr:    s.stderr.String(),
+	}
+	if s.cmd != nil && s.cmd.Process != nil {
+		status.PID = s.cmd.Process.Pid
+	}
+	if s.lastErr != nil {
+		status.LastError = s.lastErr.Error()
+	}
+
+	return status
+}
+
+// shutdown stops restarting the plugin and sends SIGTERM to the process. The
+// process is killed if it hasn't exited when timeout is closed.
+func (s *supervisor) shutdown(timeout <-chan struct{}) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	s.mu.Lock()
+	cmd := s.cmd
+	s.mu.Unlock()
+
+	if cmd != nil && cmd.Process != nil {
+		if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			log.Printf("Failed to send SIGTERM to plugin %s: %v", s.cfg.ID, err)
+			if err := cmd.Process.Kill(); err != nil {
+				return fmt.Errorf("failed to kill plugin %s: %w", s.cfg.ID, err)
+			}
+		}
+	}
+
+	select {
+	case <-s.done:
+		return nil
+	case <-timeout:
+		// Timeout - force kill
+		if cmd != nil && cmd.Process != nil {
+			if err := cmd.Process.Kill(); err != nil {
+				return fmt.Errorf("failed to kill plugin %s after timeout: %w", s.cfg.ID, err)
+			}
+		}
+		<-s.done
+		return nil
+	}
+}
+
+// ringBuffer keeps the last size bytes written to it
+type ringBuffer struct {
+	mu   sync.Mutex
+	size int
+	buf  []byte
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{size: size}
+}
+
+func (r *ringBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf = append(r.buf, p...)
+	if over := len(r.buf) - r.size; over > 0 {
+		// Drop whole lines where possible
+		if n := bytes.IndexByte(r.buf[over:], '\n'); n >= 0 && n < r.size/4 {
+			over += n + 1
+		}
+		r.buf = append(r.buf[:0], r.buf[over:]...)
+	}
+
+	return len(p), nil
+}
+
+func (r *ringBuffer) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return string(r.buf)
+}
@@ -0,0 +1,272 @@
+// Package canonical encodes objects stored in the CAS as canonical JSON, as
+// specified by RFC 8785 (JSON Canonicalization Scheme). The same value always
+// encodes to the same bytes, and so to the same hash, regardless of the
+// version of the protobuf library: keys are sorted, there is no whitespace and
+// numbers are formatted like ECMAScript does.
+package canonical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Marshal encodes v as canonical JSON. Protobuf messages are encoded with
+// protojson using the proto field names, other values with encoding/json.
+func Marshal(v any) ([]byte, error) {
+	var b []byte
+	var err error
+	if m, ok := v.(proto.Message); ok {
+		b, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	} else {
+		b, err = json.Marshal(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return Transform(b)
+}
+
+// Unmarshal decodes JSON into v, like Marshal encodes it. It reads both
+// canonical JSON and the indented protojson and encoding/json output stored
+// before objects were canonicalized.
+func Unmarshal(b []byte, v any) error {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Unmarshal(b, m)
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Transform converts any JSON document to its canonical form.
+func Transform(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := writeValue(&buf, dec); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// IsCanonical reports whether b is canonical JSON.
+func IsCanonical(b []byte) bool {
+	c, err := Transform(b)
+	return err == nil && bytes.Equal(b, c)
+}
+
+func writeValue(buf *bytes.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			return writeObject(buf, dec)
+		}
+		return writeArray(buf, dec)
+	case string:
+		writeString(buf, t)
+	case json.Number:
+		s, err := formatNumber(t)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case bool:
+		buf.WriteString(strconv.FormatBool(t))
+	case nil:
+		buf.WriteString("null")
+	}
+
+	return nil
+}
+
+type member struct {
+	key   string
+	value []byte
+}
+
+func writeObject(buf *bytes.Buffer, dec *json.Decoder) error {
+	var members []member
+	seen := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		key := tok.(string)
+		if seen[key] {
+			return fmt.Errorf("duplicate key: %q", key)
+		}
+		seen[key] = true
+
+		var value bytes.Buffer
+		if err := writeValue(&value, dec); err != nil {
+			return err
+		}
+		members = append(members, member{key: key, value: value.Bytes()})
+	}
+
+	// Closing brace
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	// Keys are sorted by their UTF-16 code units
+	sort.Slice(members, func(a, b int) bool {
+		return lessUTF16(members[a].key, members[b].key)
+	})
+
+	buf.WriteByte('{')
+	for n, m := range members {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		writeString(buf, m.key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func writeArray(buf *bytes.Buffer, dec *json.Decoder) error {
+	buf.WriteByte('[')
+	for n := 0; dec.More(); n++ {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeValue(buf, dec); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+
+	// Closing bracket
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return nil
+}
+
+func lessUTF16(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for n := 0; n < len(ua) && n < len(ub); n++ {
+		if ua[n] != ub[n] {
+			return ua[n] < ub[n]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// writeString escapes only what JSON requires, using the short escapes where
+// there is one.
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				var b [utf8.UTFMax]byte
+				buf.Write(b[:utf8.EncodeRune(b[:], r)])
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatNumber formats a number like ECMAScript's Number.prototype.toString.
+// Numbers are IEEE 754 doubles, integers that a double can't hold exactly are
+// rejected rather than silently rounded.
+func formatNumber(n json.Number) (string, error) {
+	f, err := strconv.ParseFloat(n.String(), 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("invalid number: %s", n)
+	}
+
+	if !strings.ContainsAny(n.String(), ".eE") {
+		i, ok := new(big.Int).SetString(n.String(), 10)
+		exact, _ := new(big.Float).SetFloat64(f).Int(nil)
+		if !ok || i.Cmp(exact) != 0 {
+			return "", fmt.Errorf("number can't be represented exactly: %s", n)
+		}
+	}
+
+	if f == 0 {
+		return "0", nil
+	}
+
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+
+	mantissa, exponent, _ := strings.Cut(s, "e")
+	e, err := strconv.Atoi(exponent)
+	if err != nil {
+		return "", fmt.Errorf("invalid number: %s", n)
+	}
+
+	digits := strings.Replace(mantissa, ".", "", 1)
+	k := len(digits)
+	// Position of the decimal point relative to the digits
+	p := e + 1
+
+	switch {
+	case k <= p && p <= 21:
+		return sign + digits + strings.Repeat("0", p-k), nil
+	case 0 < p && p <= 21:
+		return sign + digits[:p] + "." + digits[p:], nil
+	case -6 < p && p <= 0:
+		return sign + "0." + strings.Repeat("0", -p) + digits, nil
+	}
+
+	s = digits[:1]
+	if k > 1 {
+		s += "." + digits[1:]
+	}
+	if e < 0 {
+		return sign + s + "e-" + strconv.Itoa(-e), nil
+	}
+	return sign + s + "e+" + strconv.Itoa(e), nil
+}
@@ -0,0 +1,93 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"perkeep.org/pkg/blob"
+)
+
+// Memory is a CAS kept in memory, hashed like Perkeep. It is meant for tools
+// that need a throwaway store.
+type Memory struct {
+	mu     sync.RWMutex
+	blobs  map[string][]byte
+	hashes []string
+}
+
+func NewMemory() *Memory {
+	return &Memory{blobs: make(map[string][]byte)}
+}
+
+func (m *Memory) Store(ctx context.Context, r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	h := blob.NewHash()
+	if _, err := h.Write(b); err != nil {
+		return "", err
+	}
+	hash := blob.RefFromHash(h).String()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.blobs[hash]; !ok {
+		m.hashes = append(m.hashes, hash)
+	}
+	m.blobs[hash] = b
+
+	return hash, nil
+}
+
+func (m *Memory) Retrieve(ctx context.Context, hash string) (io.ReadCloser, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.blobs[hash]
+	if !ok {
+		return nil, fmt.Errorf("blob not found: %s", hash)
+	}
+
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m *Memory) Iterate(ctx context.Context) (<-chan string, error) {
+	m.mu.RLock()
+	hashes := slices.Clone(m.hashes)
+	m.mu.RUnlock()
+
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for _, hash := range hashes {
+			select {
+			case ch <- hash:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (m *Memory) Delete(ctx context.Context, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.blobs[hash]; !ok {
+		return nil
+	}
+	delete(m.blobs, hash)
+	m.hashes = slices.DeleteFunc(m.hashes, func(h string) bool {
+		return h == hash
+	})
+	return nil
+}
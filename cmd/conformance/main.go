@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.quinn.io/dataq/conformance"
+	"go.quinn.io/dataq/rpc"
+)
+
+// requests collects repeated flags
+type requests []string
+
+func (r *requests) String() string     { return strings.Join(*r, ",") }
+func (r *requests) Set(v string) error { *r = append(*r, v); return nil }
+
+// Usage: conformance [flags] <plugin binary>
+//
+//	-id gmail                                 expected plugin id
+//	-extract 'get_message?message_id=abc'     request for an extract kind
+//	-transform 'attachment?part_id=1@a.pdf'   sample content for a transform kind
+//	-oauth oauth.json                         OAuth2 config and token sent with extracts
+func main() {
+	var extracts, transforms requests
+	id := flag.String("id", "", "expected plugin id")
+	oauthPath := flag.String("oauth", "", "path of an OAuth2 message in JSON, sent with extracts")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of each call to the plugin")
+	flag.Var(&extracts, "extract", "extract request as kind?key=value&key=value, repeatable")
+	flag.Var(&transforms, "transform", "transform sample as kind?key=value@path, repeatable")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: conformance [flags] <plugin binary>")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	opts := conformance.Options{
+		PluginID: *id,
+		Timeout:  *timeout,
+	}
+
+	var oauth *rpc.OAuth2
+	if *oauthPath != "" {
+		b, err := os.ReadFile(*oauthPath)
+		if err != nil {
+			log.Fatalf("Failed to read OAuth2: %v", err)
+		}
+		oauth = &rpc.OAuth2{}
+		if err := protojson.Unmarshal(b, oauth); err != nil {
+			log.Fatalf("Failed to parse OAuth2: %v", err)
+		}
+	}
+
+	for _, e := range extracts {
+		kind, metadata, err := parseKind(e)
+		if err != nil {
+			log.Fatalf("Failed to parse extract %q: %v", e, err)
+		}
+		opts.Extracts = append(opts.Extracts, &rpc.ExtractRequest{Kind: kind, Oauth: oauth, Metadata: metadata})
+	}
+
+	for _, t := range transforms {
+		n := strings.LastIndex(t, "@")
+		if n < 0 {
+			log.Fatalf("Transform %q has no @path", t)
+		}
+		kind, metadata, err := parseKind(t[:n])
+		if err != nil {
+			log.Fatalf("Failed to parse transform %q: %v", t, err)
+		}
+
+		content, err := os.ReadFile(t[n+1:])
+		if err != nil {
+			log.Fatalf("Failed to read transform content: %v", err)
+		}
+		opts.Transforms = append(opts.Transforms, &rpc.TransformRequest{
+			Kind:     kind,
+			Data:     &rpc.TransformRequest_Content{Content: content},
+			Metadata: metadata,
+		})
+	}
+
+	report, err := conformance.RunBinary(context.Background(), flag.Arg(0), opts)
+	if err != nil {
+		log.Fatalf("Failed to run conformance suite: %v", err)
+	}
+
+	report.Print(os.Stdout)
+	if report.Failed() {
+		os.Exit(1)
+	}
+}
+
+// parseKind parses kind?key=value&key=value
+func parseKind(s string) (string, map[string]string, error) {
+	kind, query, _ := strings.Cut(s, "?")
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	metadata := make(map[string]string)
+	for key := range values {
+		metadata[key] = values.Get(key)
+	}
+	return kind, metadata, nil
+}
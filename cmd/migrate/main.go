@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"go.quinn.io/dataq/boot"
+	"go.quinn.io/dataq/index"
+)
+
+// Usage: migrate [kind...]
+// Without kinds, every kind with a registered migration is migrated.
+func main() {
+	b, err := boot.New()
+	if err != nil {
+		log.Fatalf("Failed to initialize boot: %v", err)
+	}
+
+	kinds := os.Args[1:]
+	if len(kinds) == 0 {
+		kinds = index.MigrationKinds()
+	}
+
+	for _, kind := range kinds {
+		n, err := b.Index.Migrate(context.Background(), kind)
+		if err != nil {
+			log.Fatalf("Failed to migrate %s: %v", kind, err)
+		}
+		log.Printf("Migrated %d %s permanodes", n, kind)
+	}
+}
@@ -1,88 +1,137 @@
 package main
 
-// import (
-// 	"context"
-// 	"io/fs"
-// 	"path/filepath"
-
-// 	"go.quinn.io/dataq/plugin"
-// 	"go.quinn.io/dataq/schema"
-// )
-
-// type FileScanPlugin struct {
-// 	rootPath string
-// }
-
-// func New() *FileScanPlugin {
-// 	return &FileScanPlugin{}
-// }
-
-// func (p *FileScanPlugin) ID() string {
-// 	return "filescan"
-// }
-
-// func (p *FileScanPlugin) Name() string {
-// 	return "File System Scanner"
-// }
-
-// func (p *FileScanPlugin) Description() string {
-// 	return "Scans specified directories for files and returns their metadata"
-// }
-
-// func (p *FileScanPlugin) Configure(config map[string]string) error {
-// 	if path, ok := config["root_path"]; ok {
-// 		p.rootPath = path
-// 	} else {
-// 		p.rootPath = "."
-// 	}
-// 	return nil
-// }
-
-// func (p *FileScanPlugin) Extract(ctx context.Context, action *schema.Action, api *plugin.PluginAPI) error {
-// 	err := filepath.WalkDir(p.rootPath, func(path string, d fs.DirEntry, err error) error {
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		select {
-// 		case <-ctx.Done():
-// 			return ctx.Err()
-// 		default:
-// 		}
-
-// 		if d.IsDir() {
-// 			return nil
-// 		}
-
-// 		info, err := d.Info()
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		item := &schema.DataItem{
-// 			Meta: &schema.DataItemMetadata{
-// 				PluginId:    p.ID(),
-// 				Id:          path,
-// 				Kind:        "file",
-// 				Timestamp:   info.ModTime().Unix(),
-// 				ContentType: "application/octet-stream",
-// 			},
-// 			RawData: []byte{},
-// 		}
-
-// 		api.WriteItem(item)
-
-// 		return nil
-// 	})
-
-// 	if err != nil {
-// 		// In a real implementation, we'd want to handle this error better
-// 		return err
-// 	}
-
-// 	return nil
-// }
-
-// func (p *FileScanPlugin) Transform(ctx context.Context, item *schema.DataItem, api *plugin.PluginAPI) error {
-// 	return nil
-// }
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.quinn.io/dataq/handshake"
+	"go.quinn.io/dataq/plugin"
+	"go.quinn.io/dataq/rpc"
+)
+
+// maxFileSize is the largest file sent to the host, leaving room for the rest
+// of the response
+const maxFileSize = handshake.MaxMessageSize - 1<<20
+
+type scanParams struct {
+	RootPath string `config:"root_path" label:"Root Path"`
+	Pattern  string `config:"pattern,optional" label:"File Name Pattern"`
+}
+
+// scan sends every regular file under the root path modified since the last
+// scan of that path, with its content.
+func scan(ctx context.Context, req *plugin.ExtractRequest, params scanParams, send func(*rpc.ExtractResponse) error) error {
+	root, err := filepath.Abs(params.RootPath)
+	if err != nil {
+		return fmt.Errorf("invalid root path: %w", err)
+	}
+	if _, err := filepath.Match(params.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	// Every item carries the latest modification time sent so far, the host
+	// applies the one of the last item once every item is stored
+	stateKey := "modified_since:" + root
+	var since int64
+	if v := req.State[stateKey]; len(v) > 0 {
+		if since, err = strconv.ParseInt(string(v), 10, 64); err != nil {
+			return fmt.Errorf("invalid %s state: %w", stateKey, err)
+		}
+	}
+	latest := since
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if params.Pattern != "" {
+			if ok, _ := filepath.Match(params.Pattern, d.Name()); !ok {
+				return nil
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		modified := info.ModTime().UnixNano()
+		if modified <= since {
+			return nil
+		}
+		if info.Size() > maxFileSize {
+			log.Printf("Skipping %s, %d bytes is over %d", path, info.Size(), maxFileSize)
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		latest = max(latest, modified)
+		return send(&rpc.ExtractResponse{
+			Kind: "file",
+			Data: &rpc.ExtractResponse_Content{Content: content},
+			Transforms: []*rpc.ExtractResponse_Transform{{
+				Kind: "file",
+				Metadata: map[string]string{
+					"path":        path,
+					"modified_at": info.ModTime().UTC().Format(time.RFC3339),
+				},
+			}},
+			State: map[string][]byte{
+				stateKey: []byte(strconv.FormatInt(latest, 10)),
+			},
+		})
+	})
+}
+
+func transformFile(_ context.Context, req *plugin.TransformRequest, data []byte) (*rpc.TransformResponse, error) {
+	path := req.Metadata["path"]
+
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+
+	doc := &rpc.Document{
+		Path:       path,
+		Name:       filepath.Base(path),
+		MimeType:   mimeType,
+		Size:       int64(len(data)),
+		ModifiedAt: req.Metadata["modified_at"],
+		DataHash:   req.DataHash,
+	}
+
+	// Index the text of text files for search
+	if strings.HasPrefix(mimeType, "text/") && utf8.Valid(data) {
+		doc.Text = string(data)
+	}
+
+	return &rpc.TransformResponse{
+		Kind: "file",
+		Permanodes: []*rpc.TransformResponse_Permanode{{
+			Kind: "document",
+			Key:  path,
+			Payload: &rpc.TransformResponse_Permanode_Document{
+				Document: doc,
+			},
+		}},
+	}, nil
+}
@@ -1,10 +1,20 @@
 package main
 
-// import (
-// 	"go.quinn.io/dataq/plugin"
-// )
+import (
+	"go.quinn.io/dataq/plugin"
+)
 
 func main() {
-	// p := New()
-	// plugin.Run(p)
+	p := plugin.New("filescan")
+
+	plugin.HandleExtractStream(p, plugin.Extract{
+		Kind:        "scan",
+		Label:       "Scan",
+		Description: "Scan a directory for files added or modified since the last scan",
+	}, scan)
+
+	// Transforms only depend on the extracted data
+	plugin.HandleTransform(p, "file", plugin.Never(), transformFile)
+
+	plugin.Run(p)
 }
@@ -4,155 +4,68 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"time"
 
+	"go.quinn.io/dataq/plugin"
 	"go.quinn.io/dataq/rpc"
-	"golang.org/x/oauth2"
 )
 
-// FitbitClient represents a client for Fitbit API
-type FitbitClient struct {
-	oauthConfig *oauth2.Config
-	oauthToken  *oauth2.Token
+type requestParams struct {
+	RequestPath string `config:"request_path,optional" label:"Request Path"`
 }
 
-// NewFitbitClient initializes a new FitbitClient with the given credentials
-func NewFitbitClient(oauth *rpc.OAuth2) *FitbitClient {
-	if oauth.Config == nil {
-		log.Fatal("OauthConfig not set in CONFIG")
-	}
+// lastDateKey is the sync state key of the last day steps were fetched for
+const lastDateKey = "last_date"
 
-	if oauth.Token == nil {
-		log.Fatal("OauthToken not set in CONFIG")
+// makeRequest fetches a path of the Fitbit API. If no path is given, it
+// fetches the steps since the last day fetched, or of the last 30 days the
+// first time.
+func makeRequest(ctx context.Context, req *plugin.ExtractRequest, params requestParams) (*rpc.ExtractResponse, error) {
+	client, err := req.Client(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Println("Using config:", oauth)
+	var state map[string][]byte
+	path := params.RequestPath
+	if path == "" {
+		// Format dates in the required format (YYYY-MM-DD)
+		today := time.Now().Format(time.DateOnly)
+		start := time.Now().AddDate(0, 0, -29).Format(time.DateOnly)
 
-	client := &FitbitClient{
-		oauthConfig: &oauth2.Config{
-			ClientID:     oauth.Config.ClientId,
-			ClientSecret: oauth.Config.ClientSecret,
-			RedirectURL:  oauth.Config.RedirectUrl,
-			Scopes:       oauth.Config.Scopes,
+		// The last day is fetched again, its steps were still changing
+		if last := string(req.State[lastDateKey]); last != "" && last > start {
+			start = last
+		}
 
-			Endpoint: oauth2.Endpoint{
-				AuthURL:  oauth.Config.Endpoint.AuthUrl,
-				TokenURL: oauth.Config.Endpoint.TokenUrl,
-			},
-		},
-		oauthToken: &oauth2.Token{
-			AccessToken:  oauth.Token.AccessToken,
-			TokenType:    oauth.Token.TokenType,
-			RefreshToken: oauth.Token.RefreshToken,
-			ExpiresIn:    oauth.Token.ExpiresIn,
-			Expiry:       time.UnixMilli(oauth.Token.Expiry),
-		},
+		path = fmt.Sprintf("/1/user/-/activities/steps/date/%s/%s.json", start, today)
+		state = map[string][]byte{lastDateKey: []byte(today)}
 	}
 
-	// Try to load existing token
-	return client
-}
-
-// // GetAuthURL generates the URL for user authorization
-// func (c *FitbitClient) GetAuthURL() string {
-// 	return c.oauthConfig.AuthCodeURL("state", oauth2.AccessTypeOffline)
-// }
-
-// RefreshToken refreshes the OAuth token if it's expired
-// func (c *FitbitClient) RefreshToken(ctx context.Context) error {
-// 	if c.config.AccessToken == nil {
-// 		return fmt.Errorf("no token available to refresh")
-// 	}
-
-// 	token, err := c.oauthConfig.TokenSource(ctx, c.config.AccessToken).Token()
-// 	if err != nil {
-// 		return fmt.Errorf("failed to refresh token: %v", err)
-// 	}
-
-// 	c.config.AccessToken = token
-// 	if err := c.saveToken(); err != nil {
-// 		return fmt.Errorf("failed to save refreshed token: %v", err)
-// 	}
-
-// 	return nil
-// }
-
-// loadToken attempts to load the token from the filesystem
-// func (c *FitbitClient) loadToken() error {
-// 	data, err := os.ReadFile(c.tokenPath)
-// 	if err != nil {
-// 		return fmt.Errorf("failed to read token file: %v", err)
-// 	}
-
-// 	token := &oauth2.Token{}
-// 	if err := json.Unmarshal(data, token); err != nil {
-// 		return fmt.Errorf("failed to parse token: %v", err)
-// 	}
-
-// 	c.token = token
-// 	return nil
-// }
-
-// saveToken saves the current token to the filesystem
-// func (c *FitbitClient) saveToken() error {
-// 	if c.token == nil {
-// 		return fmt.Errorf("no token to save")
-// 	}
-
-// 	// Ensure directory exists
-// 	dir := filepath.Dir(c.tokenPath)
-// 	if err := os.MkdirAll(dir, 0700); err != nil {
-// 		return fmt.Errorf("failed to create token directory: %v", err)
-// 	}
-
-// 	data, err := json.Marshal(c.token)
-// 	if err != nil {
-// 		return fmt.Errorf("failed to marshal token: %v", err)
-// 	}
-
-// 	if err := os.WriteFile(c.tokenPath, data, 0600); err != nil {
-// 		return fmt.Errorf("failed to save token: %v", err)
-// 	}
-
-// 	return nil
-// }
-
-// // Token returns the current OAuth2 token
-// func (c *FitbitClient) Token() *oauth2.Token {
-// 	return c.token
-// }
-
-// // HasValidToken checks if the client has a valid token
-// func (c *FitbitClient) HasValidToken() bool {
-// 	return c.token != nil && c.token.Valid()
-// }
+	data, err := get(ctx, client, "https://api.fitbit.com"+path)
+	if err != nil {
+		return nil, err
+	}
 
-// StepData represents the step count data from Fitbit API
-type StepData struct {
-	Activities []struct {
-		Steps int `json:"steps"`
-	} `json:"activities-steps"`
+	return &rpc.ExtractResponse{
+		Kind: "steps",
+		Data: &rpc.ExtractResponse_Content{
+			Content: data,
+		},
+		State: state,
+	}, nil
 }
 
-// GetTodaySteps fetches the step count for the current day
-func (c *FitbitClient) GetTodaySteps(ctx context.Context) ([]byte, error) {
-	// if !c.HasValidToken() {
-	// 	return nil, fmt.Errorf("no valid token available")
-	// }
-
-	client := c.oauthConfig.Client(ctx, c.oauthToken)
-
-	// Format today's date in the required format (YYYY-MM-DD)
-	today := time.Now().Format("2006-01-02")
-
-	// Construct the Fitbit API URL for daily activity summary
-	url := fmt.Sprintf("https://api.fitbit.com/1/user/-/activities/steps/date/%s/30d.json", today)
+func get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
 
-	resp, err := client.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch steps data: %v", err)
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
@@ -162,16 +75,3 @@ func (c *FitbitClient) GetTodaySteps(ctx context.Context) ([]byte, error) {
 
 	return io.ReadAll(resp.Body)
 }
-
-func (c *FitbitClient) MakeRequest(ctx context.Context, path string) ([]byte, error) {
-	client := c.oauthConfig.Client(ctx, c.oauthToken)
-
-	url := fmt.Sprintf("https://api.fitbit.com%s", path)
-
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch steps data: %v", err)
-	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
-}
@@ -1,34 +1,23 @@
 package main
 
 import (
-	"fmt"
-	"log"
-	"net"
-	"os"
+	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
-
-	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/plugin"
+	"golang.org/x/oauth2/fitbit"
 )
 
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		log.Fatal("PORT environment variable not set")
-	}
-
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
-	}
+	p := plugin.New("fitbit")
+	p.OAuth(fitbit.Endpoint, "activity", "heartrate", "profile", "sleep", "weight")
 
-	s := grpc.NewServer()
-	rpc.RegisterDataQPluginServer(s, NewServer())
-	reflection.Register(s)
+	plugin.HandleExtract(p, plugin.Extract{
+		Kind:        "make_request",
+		Label:       "Make a Request",
+		Description: "Make a request to the Fitbit API by specifing the request path",
+		// Today's steps keep changing
+		Freshness: plugin.TTL(15 * time.Minute),
+	}, makeRequest)
 
-	log.Printf("server listening at %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
-	}
+	plugin.Run(p)
 }
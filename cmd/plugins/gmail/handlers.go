@@ -0,0 +1,460 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.quinn.io/dataq/plugin"
+	"go.quinn.io/dataq/rpc"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type nextPageParams struct {
+	NextPageToken string `config:"next_page_token" label:"Next Page Token"`
+}
+
+type messageParams struct {
+	MessageID string `config:"message_id" label:"Message ID"`
+}
+
+type attachmentParams struct {
+	MessageID    string `config:"message_id" label:"Message ID"`
+	AttachmentID string `config:"attachment_id" label:"Attachment ID"`
+}
+
+func extractInitial(ctx context.Context, req *plugin.ExtractRequest, _ struct{}) (*rpc.ExtractResponse, error) {
+	return extractPage(ctx, req, "")
+}
+
+func extractNextPage(ctx context.Context, req *plugin.ExtractRequest, params nextPageParams) (*rpc.ExtractResponse, error) {
+	return extractPage(ctx, req, params.NextPageToken)
+}
+
+func extractPage(ctx context.Context, req *plugin.ExtractRequest, pageToken string) (*rpc.ExtractResponse, error) {
+	srv, err := service(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %v", err)
+	}
+	gmailReq := srv.Users.Messages.List("me").MaxResults(100)
+
+	if pageToken != "" {
+		gmailReq.PageToken(pageToken)
+	}
+
+	r, err := gmailReq.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing messages: %v", err)
+	}
+
+	if r.Messages == nil {
+		return nil, fmt.Errorf("no messages found")
+	}
+
+	// Store the raw response
+	rawJSON, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling response: %v", err)
+	}
+
+	resp := &rpc.ExtractResponse{
+		Kind: "page",
+		Data: &rpc.ExtractResponse_Content{Content: rawJSON},
+		Transforms: []*rpc.ExtractResponse_Transform{{
+			Kind: "page",
+		}},
+	}
+
+	return resp, nil
+}
+
+// historyIDKey is the sync state key of the mailbox history id changes are
+// listed from
+const historyIDKey = "history_id"
+
+// extractSync lists the messages added since the last sync. The first sync,
+// and a sync whose history id expired, lists every message instead.
+func extractSync(ctx context.Context, req *plugin.ExtractRequest, _ struct{}) (*rpc.ExtractResponse, error) {
+	srv, err := service(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %v", err)
+	}
+
+	if historyID, err := strconv.ParseUint(string(req.State[historyIDKey]), 10, 64); err == nil {
+		res, err := extractHistory(ctx, srv, historyID)
+		if !isNotFound(err) {
+			return res, err
+		}
+		log.Printf("History %d expired, listing every message", historyID)
+	}
+
+	// Changes after the profile was read are picked up by the next sync
+	profile, err := srv.Users.GetProfile("me").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting profile: %v", err)
+	}
+
+	res, err := extractPage(ctx, req, "")
+	if err != nil {
+		return nil, err
+	}
+	res.State = map[string][]byte{
+		historyIDKey: []byte(strconv.FormatUint(profile.HistoryId, 10)),
+	}
+
+	return res, nil
+}
+
+// extractHistory lists the messages added after historyID, every page at once.
+func extractHistory(ctx context.Context, srv *gmail.Service, historyID uint64) (*rpc.ExtractResponse, error) {
+	history := &gmail.ListHistoryResponse{HistoryId: historyID}
+	err := srv.Users.History.List("me").
+		StartHistoryId(historyID).
+		HistoryTypes("messageAdded").
+		Pages(ctx, func(r *gmail.ListHistoryResponse) error {
+			history.History = append(history.History, r.History...)
+			history.HistoryId = r.HistoryId
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error listing history: %w", err)
+	}
+
+	rawJSON, err := json.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling history: %v", err)
+	}
+
+	return &rpc.ExtractResponse{
+		Kind: "history",
+		Data: &rpc.ExtractResponse_Content{Content: rawJSON},
+		Transforms: []*rpc.ExtractResponse_Transform{{
+			Kind: "history",
+		}},
+		State: map[string][]byte{
+			historyIDKey: []byte(strconv.FormatUint(history.HistoryId, 10)),
+		},
+	}, nil
+}
+
+// isNotFound reports whether err is the response to an expired history id.
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+func extractMessage(ctx context.Context, req *plugin.ExtractRequest, params messageParams) (*rpc.ExtractResponse, error) {
+	srv, err := service(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %v", err)
+	}
+
+	msg, err := srv.Users.Messages.Get("me", params.MessageID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting message: %v", err)
+	}
+
+	// Store the raw response
+	rawJSON, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling message: %v", err)
+	}
+
+	resp := &rpc.ExtractResponse{
+		Kind: "message",
+		Data: &rpc.ExtractResponse_Content{Content: rawJSON},
+		Transforms: []*rpc.ExtractResponse_Transform{{
+			Kind: "message",
+		}},
+	}
+
+	return resp, nil
+}
+
+func extractAttachment(ctx context.Context, req *plugin.ExtractRequest, params attachmentParams) (*rpc.ExtractResponse, error) {
+	srv, err := service(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %v", err)
+	}
+
+	body, err := srv.Users.Messages.Attachments.Get("me", params.MessageID, params.AttachmentID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting attachment: %v", err)
+	}
+
+	// Store the decoded file rather than the API response
+	data, err := decodeData(body.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding attachment: %v", err)
+	}
+
+	resp := &rpc.ExtractResponse{
+		Kind: "attachment",
+		Data: &rpc.ExtractResponse_Content{Content: data},
+		Transforms: []*rpc.ExtractResponse_Transform{{
+			Kind:     "attachment",
+			Metadata: req.Metadata,
+		}},
+	}
+
+	return resp, nil
+}
+
+func transformPage(_ context.Context, _ *plugin.TransformRequest, pageData gmail.ListMessagesResponse) (*rpc.TransformResponse, error) {
+	resp := &rpc.TransformResponse{
+		Kind: "page",
+	}
+
+	// If there's a next page, add an extract for it
+	if pageData.NextPageToken != "" {
+		resp.Extracts = append(resp.Extracts, &rpc.TransformResponse_Extract{
+			Kind: "next_page",
+			Metadata: map[string]string{
+				"next_page_token": pageData.NextPageToken,
+			},
+		})
+	}
+
+	// Create extract requests for each message
+	for _, msg := range pageData.Messages {
+		resp.Extracts = append(resp.Extracts, &rpc.TransformResponse_Extract{
+			Kind: "get_message",
+			Metadata: map[string]string{
+				"message_id": msg.Id,
+			},
+		})
+	}
+
+	return resp, nil
+}
+
+func transformHistory(_ context.Context, _ *plugin.TransformRequest, history gmail.ListHistoryResponse) (*rpc.TransformResponse, error) {
+	resp := &rpc.TransformResponse{
+		Kind: "history",
+	}
+
+	seen := make(map[string]bool)
+	for _, h := range history.History {
+		for _, added := range h.MessagesAdded {
+			if added.Message == nil || seen[added.Message.Id] {
+				continue
+			}
+			seen[added.Message.Id] = true
+
+			resp.Extracts = append(resp.Extracts, &rpc.TransformResponse_Extract{
+				Kind: "get_message",
+				Metadata: map[string]string{
+					"message_id": added.Message.Id,
+				},
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func transformMessage(_ context.Context, _ *plugin.TransformRequest, msgData gmail.Message) (*rpc.TransformResponse, error) {
+	// Extract email fields from Gmail message
+	email, err := extractEmailFromMessage(&msgData)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &rpc.TransformResponse{
+		Kind: "message",
+		Permanodes: []*rpc.TransformResponse_Permanode{{
+			Kind: "email",
+			Key:  msgData.Id,
+			Payload: &rpc.TransformResponse_Permanode_Email{
+				Email: email,
+			},
+		}},
+	}
+
+	// Attachments are fetched separately so their content is stored in the CAS
+	for _, part := range attachmentParts(msgData.Payload) {
+		resp.Extracts = append(resp.Extracts, &rpc.TransformResponse_Extract{
+			Kind: "get_attachment",
+			Metadata: map[string]string{
+				"message_id":    msgData.Id,
+				"attachment_id": part.Body.AttachmentId,
+				"part_id":       part.PartId,
+				"filename":      part.Filename,
+				"mime_type":     part.MimeType,
+			},
+		})
+	}
+
+	return resp, nil
+}
+
+func transformAttachment(_ context.Context, req *plugin.TransformRequest, data []byte) (*rpc.TransformResponse, error) {
+	messageID := req.Metadata["message_id"]
+	partID := req.Metadata["part_id"]
+
+	resp := &rpc.TransformResponse{
+		Kind: "attachment",
+		Permanodes: []*rpc.TransformResponse_Permanode{{
+			Kind: "attachment",
+			// attachment ids change between requests, the part id does not
+			Key: messageID + "/" + partID,
+			Payload: &rpc.TransformResponse_Permanode_Attachment{
+				Attachment: &rpc.Attachment{
+					MessageId: messageID,
+					PartId:    partID,
+					Filename:  req.Metadata["filename"],
+					MimeType:  req.Metadata["mime_type"],
+					Size:      int64(len(data)),
+					DataHash:  req.DataHash,
+				},
+			},
+		}},
+	}
+
+	return resp, nil
+}
+
+func extractEmailFromMessage(msg *gmail.Message) (*rpc.Email, error) {
+	email := &rpc.Email{
+		MessageId: msg.Id,
+		ThreadId:  msg.ThreadId,
+		Labels:    msg.LabelIds,
+		Size:      msg.SizeEstimate,
+		Snippet:   msg.Snippet,
+	}
+
+	if msg.InternalDate != 0 {
+		email.Date = timestamppb.New(time.UnixMilli(msg.InternalDate))
+	}
+
+	if msg.Payload == nil {
+		return email, nil
+	}
+
+	for _, header := range msg.Payload.Headers {
+		switch header.Name {
+		case "From":
+			if from := parseAddresses(header.Value); len(from) > 0 {
+				email.From = from[0]
+			}
+		case "To":
+			email.To = parseAddresses(header.Value)
+		case "Cc":
+			email.Cc = parseAddresses(header.Value)
+		case "Bcc":
+			email.Bcc = parseAddresses(header.Value)
+		case "Reply-To":
+			email.ReplyTo = parseAddresses(header.Value)
+		case "Subject":
+			email.Subject = header.Value
+		case "Date":
+			// Prefer the date the message was sent over the date it was received
+			if t, err := mail.ParseDate(header.Value); err == nil {
+				email.Date = timestamppb.New(t)
+			}
+		case "In-Reply-To":
+			email.InReplyTo = strings.TrimSpace(header.Value)
+		case "References":
+			email.References = strings.Fields(header.Value)
+		}
+	}
+
+	if err := extractBodies(email, msg.Payload); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+// extractBodies walks the message parts, decoding the first text and html
+// bodies and collecting attachments.
+func extractBodies(email *rpc.Email, part *gmail.MessagePart) error {
+	if part.Filename != "" {
+		var size int64
+		if part.Body != nil {
+			size = part.Body.Size
+		}
+		email.Attachments = append(email.Attachments, &rpc.Attachment{
+			MessageId: email.MessageId,
+			PartId:    part.PartId,
+			Filename:  part.Filename,
+			MimeType:  part.MimeType,
+			Size:      size,
+		})
+		return nil
+	}
+
+	if part.Body != nil && part.Body.Data != "" {
+		data, err := decodeData(part.Body.Data)
+		if err != nil {
+			return fmt.Errorf("error decoding %s body: %v", part.MimeType, err)
+		}
+
+		switch {
+		case part.MimeType == "text/html" && email.Html == "":
+			email.Html = string(data)
+		case part.MimeType == "text/plain" && email.Text == "":
+			email.Text = string(data)
+		}
+	}
+
+	for _, p := range part.Parts {
+		if err := extractBodies(email, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attachmentParts returns the parts whose content must be fetched separately.
+func attachmentParts(part *gmail.MessagePart) []*gmail.MessagePart {
+	if part == nil {
+		return nil
+	}
+
+	var parts []*gmail.MessagePart
+	if part.Filename != "" && part.Body != nil && part.Body.AttachmentId != "" {
+		parts = append(parts, part)
+	}
+	for _, p := range part.Parts {
+		parts = append(parts, attachmentParts(p)...)
+	}
+
+	return parts
+}
+
+// parseAddresses parses an address list header, keeping the raw value as the
+// name if it is malformed.
+func parseAddresses(value string) []*rpc.EmailAddress {
+	list, err := mail.ParseAddressList(value)
+	if err != nil {
+		if value = strings.TrimSpace(value); value == "" {
+			return nil
+		}
+		return []*rpc.EmailAddress{{Name: value}}
+	}
+
+	addresses := make([]*rpc.EmailAddress, len(list))
+	for n, a := range list {
+		addresses[n] = &rpc.EmailAddress{Name: a.Name, Email: a.Address}
+	}
+
+	return addresses
+}
+
+// decodeData decodes the base64url data of a message part. Gmail may or may
+// not pad it.
+func decodeData(data string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(data, "="))
+}
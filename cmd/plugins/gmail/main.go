@@ -1,36 +1,51 @@
 package main
 
 import (
-	"fmt"
-	"log"
-	"net"
-	"os"
+	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
-
-	pb "go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/plugin"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/gmail/v1"
 )
 
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		log.Fatal("PORT environment variable not set")
-	}
+	p := plugin.New("gmail")
+	p.OAuth(google.Endpoint, gmail.MailGoogleComScope)
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
-	}
+	plugin.HandleExtract(p, plugin.Extract{
+		Kind:        "initial",
+		Label:       "Initial",
+		Description: "Get initial page of messages",
+	}, extractInitial)
+	plugin.HandleExtract(p, plugin.Extract{
+		Kind:        "sync",
+		Label:       "Sync",
+		Description: "Get the messages added since the last sync, or every message the first time",
+	}, extractSync)
+	plugin.HandleExtract(p, plugin.Extract{
+		Kind:        "next_page",
+		Label:       "Next Page",
+		Description: "Get next page of messages",
+	}, extractNextPage)
+	plugin.HandleExtract(p, plugin.Extract{
+		Kind:        "get_message",
+		Label:       "Get Message",
+		Description: "Get a specific message",
+		// Messages don't change, but their labels do
+		Freshness: plugin.TTL(24 * time.Hour),
+	}, extractMessage)
+	plugin.HandleExtract(p, plugin.Extract{
+		Kind:        "get_attachment",
+		Label:       "Get Attachment",
+		Description: "Get an attachment of a message",
+		Freshness:   plugin.Never(),
+	}, extractAttachment)
 
-	plugin := New()
-	s := grpc.NewServer()
-	pb.RegisterDataQPluginServer(s, NewServer(plugin))
-	reflection.Register(s)
+	// Transforms only depend on the extracted data
+	plugin.HandleTransform(p, "page", plugin.Never(), transformPage)
+	plugin.HandleTransform(p, "history", plugin.Never(), transformHistory)
+	plugin.HandleTransform(p, "message", plugin.Never(), transformMessage)
+	plugin.HandleTransform(p, "attachment", plugin.Never(), transformAttachment)
 
-	log.Printf("server listening at %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
-	}
-	log.Println("Server stopped")
+	plugin.Run(p)
 }
@@ -1,7 +1,14 @@
 package main
 
 import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"go.quinn.io/dataq/rpc"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -11,13 +18,32 @@ func main() {
 			if !f.Generate {
 				continue
 			}
-			generateFile(gen, f)
+			if err := generateFile(gen, f); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 }
 
-func generateFile(gen *protogen.Plugin, file *protogen.File) {
+var (
+	timeParse    = protogen.GoIdent{GoName: "Parse", GoImportPath: "time"}
+	timeRFC3339  = protogen.GoIdent{GoName: "RFC3339Nano", GoImportPath: "time"}
+	timeDateTime = protogen.GoIdent{GoName: "DateTime", GoImportPath: "time"}
+	timeDateOnly = protogen.GoIdent{GoName: "DateOnly", GoImportPath: "time"}
+	stringsJoin  = protogen.GoIdent{GoName: "Join", GoImportPath: "strings"}
+	strconvInt   = protogen.GoIdent{GoName: "ParseInt", GoImportPath: "strconv"}
+	strconvFloat = protogen.GoIdent{GoName: "ParseFloat", GoImportPath: "strconv"}
+	fmtSprint    = protogen.GoIdent{GoName: "Sprint", GoImportPath: "fmt"}
+
+	validatePackage = protogen.GoImportPath("go.quinn.io/dataq/validate")
+	protoreflectPkg = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoreflect")
+)
+
+// fulltextColumn holds the values of all fulltext fields of a message
+const fulltextColumn = "fulltext"
+
+func generateFile(gen *protogen.Plugin, file *protogen.File) error {
 	filename := file.GeneratedFilenamePrefix + "_index.pb.go"
 	g := gen.NewGeneratedFile(filename, file.GoImportPath)
 
@@ -27,28 +53,185 @@ func generateFile(gen *protogen.Plugin, file *protogen.File) {
 	g.P()
 
 	for _, message := range file.Messages {
-		generateIndexMethods(g, message)
+		if err := generateIndexMethods(g, message); err != nil {
+			return err
+		}
+		if err := generateValidate(g, message); err != nil {
+			return err
+		}
 	}
+
+	generateRegister(g, file)
+	return nil
+}
+
+// generateValidate generates a Validate method checking the
+// (dataq.validate) options of the fields, and the fields of nested messages.
+func generateValidate(g *protogen.GeneratedFile, message *protogen.Message) error {
+	g.P("func (m *", message.GoIdent.GoName, ") Validate() error {")
+	g.P("    if m == nil {")
+	g.P("        return nil")
+	g.P("    }")
+	g.P()
+	g.P("    var errs ", validatePackage.Ident("Errors"))
+
+	for _, field := range message.Fields {
+		opts := validateOptions(field)
+		name := field.Desc.TextName()
+		value := "m.Get" + field.GoName + "()"
+
+		if opts.GetRequired() {
+			if field.Desc.IsList() || field.Desc.IsMap() {
+				g.P("    if len(", value, ") == 0 {")
+			} else {
+				g.P("    if ", value, " == ", zeroValue(field), " {")
+			}
+			g.P(`        errs.Add("`, name, `", `, validatePackage.Ident("ErrRequired"), ")")
+			g.P("    }")
+		}
+
+		if format := opts.GetFormat(); format != rpc.ValidateOptions_NONE {
+			if field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsMap() {
+				return fmt.Errorf("%s: format is only supported for string fields", field.Desc.FullName())
+			}
+
+			check := validatePackage.Ident(map[rpc.ValidateOptions_Format]string{
+				rpc.ValidateOptions_EMAIL: "Email",
+				rpc.ValidateOptions_DATE:  "Date",
+				rpc.ValidateOptions_URL:   "URL",
+			}[format])
+			if field.Desc.IsList() {
+				g.P("    for n, v := range ", value, " {")
+				g.P(`        errs.Add(`, validatePackage.Ident("Index"), `("`, name, `", n), `, check, "(v))")
+				g.P("    }")
+			} else {
+				g.P(`    errs.Add("`, name, `", `, check, "(", value, "))")
+			}
+		}
+
+		if !isMessage(field) || strings.HasPrefix(string(field.Message.Desc.FullName()), "google.protobuf.") {
+			continue
+		}
+		if field.Desc.IsList() {
+			g.P("    for n, v := range ", value, " {")
+			g.P(`        errs.Add(`, validatePackage.Ident("Index"), `("`, name, `", n), `, validatePackage.Ident("Message"), "(v))")
+			g.P("    }")
+		} else {
+			g.P(`    errs.Add("`, name, `", `, validatePackage.Ident("Message"), "(", value, "))")
+		}
+	}
+
+	g.P("    return errs.Err()")
+	g.P("}")
+	g.P()
+
+	return nil
 }
 
-func generateIndexMethods(g *protogen.GeneratedFile, message *protogen.Message) {
+// generateRegister generates a function that registers every message of the
+// file as a schema kind, with its embedded descriptor. The host passes its
+// registry as register.
+func generateRegister(g *protogen.GeneratedFile, file *protogen.File) {
+	name := "Register" + camelCase(path.Base(file.GeneratedFilenamePrefix)) + "Kinds"
+	descriptor := protoreflectPkg.Ident("MessageDescriptor")
+	message := protoreflectPkg.Ident("ProtoMessage")
+
+	g.P("// ", name, " registers the kinds declared in ", file.Desc.Path(), ".")
+	g.P("func ", name, "(register func(kind string, desc ", descriptor, ", newFn func() ", message, ")) {")
+	for _, m := range file.Messages {
+		g.P(`    register("`, m.GoIdent.GoName, `", `, file.GoDescriptorIdent, `.Messages().ByName("`, m.Desc.Name(), `"), func() `, message, " {")
+		g.P("        return new(", m.GoIdent, ")")
+		g.P("    })")
+	}
+	g.P("}")
+	g.P()
+}
+
+// camelCase converts a file name like oauth2 or my_schema to Oauth2 or MySchema
+func camelCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if r == '_' || r == '-' || r == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func generateIndexMethods(g *protogen.GeneratedFile, message *protogen.Message) error {
 	// Generate SchemaKind method
 	g.P("func (m *", message.GoIdent.GoName, ") SchemaKind() string {")
 	g.P(`    return "`, message.GoIdent.GoName, `"`)
 	g.P("}")
 	g.P()
 
+	// Generate SchemaVersion method
+	version := int32(1)
+	if opts, ok := proto.GetExtension(message.Desc.Options(), rpc.E_Kind).(*rpc.KindOptions); ok && opts.GetVersion() > 0 {
+		version = opts.GetVersion()
+	}
+	g.P("func (m *", message.GoIdent.GoName, ") SchemaVersion() int {")
+	g.P("    return ", version)
+	g.P("}")
+	g.P()
+
 	// Generate Metadata method
 	g.P("func (m *", message.GoIdent.GoName, ") SchemaMetadata() map[string]interface{} {")
 	g.P("    metadata := make(map[string]interface{})")
 	g.P()
 
+	w := &metadataWriter{g: g, fulltext: hasFulltext(message, map[*protogen.Message]bool{})}
+	if w.fulltext {
+		g.P("    var fulltext []string")
+	}
+	if err := w.fields(message, "m", "", map[*protogen.Message]bool{message: true}); err != nil {
+		return err
+	}
+
+	if w.fulltext {
+		g.P("    if len(fulltext) > 0 {")
+		g.P(`        metadata["`, fulltextColumn, `"] = `, stringsJoin, `(fulltext, "\n")`)
+		g.P("    }")
+	}
+
+	g.P("    return metadata")
+	g.P("}")
+	g.P()
+
+	return generateKey(g, message)
+}
+
+// metadataWriter generates the body of SchemaMetadata
+type metadataWriter struct {
+	g *protogen.GeneratedFile
+
+	// fulltext is set when the message has fulltext fields
+	fulltext bool
+}
+
+// fields generates the metadata of the fields of message, which is the value
+// of expr. Nested messages are flattened with their name as prefix, seen
+// guards against recursive messages.
+func (w *metadataWriter) fields(message *protogen.Message, expr, prefix string, seen map[*protogen.Message]bool) error {
+	g := w.g
+
 	// Group fields by oneof
 	oneofFields := make(map[*protogen.Oneof][]*protogen.Field)
+	var oneofs []*protogen.Oneof
 	regularFields := make([]*protogen.Field, 0)
 
 	for _, field := range message.Fields {
 		if oneof := field.Oneof; oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+			if _, ok := oneofFields[oneof]; !ok {
+				oneofs = append(oneofs, oneof)
+			}
 			oneofFields[oneof] = append(oneofFields[oneof], field)
 		} else {
 			regularFields = append(regularFields, field)
@@ -57,34 +240,210 @@ func generateIndexMethods(g *protogen.GeneratedFile, message *protogen.Message)
 
 	// Handle regular fields
 	for _, field := range regularFields {
+		opts := indexOptions(field)
+		if opts.GetSkip() {
+			continue
+		}
+
+		name := prefix + field.Desc.TextName()
+		value := expr + "." + field.GoName
+
 		if field.Desc.IsList() {
-			g.P("    if len(m.", field.GoName, ") > 0 {")
+			g.P("    if len(", value, ") > 0 {")
 		} else {
-			g.P("    if m.", field.GoName, " != ", zeroValue(field), " {")
+			g.P("    if ", value, " != ", zeroValue(field), " {")
+		}
+		if err := w.value(field, opts, value, name, seen); err != nil {
+			return err
 		}
-		g.P(`        metadata["`, field.Desc.TextName(), `"] = m.`, field.GoName)
 		g.P("    }")
 	}
 
 	// Handle oneof fields
-	for oneof, fields := range oneofFields {
-		g.P("    if m.", oneof.GoName, " != nil {")
+	for _, oneof := range oneofs {
+		g.P("    if ", expr, ".", oneof.GoName, " != nil {")
 		g.P("        switch {")
-		for _, field := range fields {
+		for _, field := range oneofFields[oneof] {
+			opts := indexOptions(field)
+			if opts.GetSkip() {
+				continue
+			}
+
+			value := expr + ".Get" + field.GoName + "()"
 			if field.Desc.IsList() {
-				g.P("        case len(m.Get", field.GoName, "()) > 0:")
+				g.P("        case len(", value, ") > 0:")
 			} else {
-				g.P("        case m.Get", field.GoName, "() != ", zeroValue(field), ":")
+				g.P("        case ", value, " != ", zeroValue(field), ":")
+			}
+			name := prefix + string(oneof.Desc.Name()) + "_" + field.Desc.TextName()
+			if err := w.value(field, opts, value, name, seen); err != nil {
+				return err
 			}
-			g.P(`            metadata["`, oneof.Desc.Name(), "_", field.Desc.TextName(), `"] = m.Get`, field.GoName, "()")
 		}
 		g.P("        }")
 		g.P("    }")
 	}
 
-	g.P("    return metadata")
+	return nil
+}
+
+// value generates the metadata of a single field that is known to be set
+func (w *metadataWriter) value(field *protogen.Field, opts *rpc.IndexOptions, value, name string, seen map[*protogen.Message]bool) error {
+	g := w.g
+	kind := field.Desc.Kind()
+
+	switch {
+	case opts.GetFulltext():
+		if kind != protoreflect.StringKind || field.Desc.IsList() {
+			return fmt.Errorf("%s: fulltext is only supported for string fields", field.Desc.FullName())
+		}
+		g.P("        fulltext = append(fulltext, ", value, ")")
+
+	case opts.GetType() == rpc.IndexOptions_DEFAULT && isTimestamp(field):
+		// Timestamps are indexed as unix milliseconds, like claim timestamps
+		g.P(`        metadata["`, name, `"] = `, value, ".AsTime().UnixMilli()")
+
+	case opts.GetType() == rpc.IndexOptions_DEFAULT && flatten(field, seen):
+		seen[field.Message] = true
+		defer delete(seen, field.Message)
+		return w.fields(field.Message, value, name+".", seen)
+
+	case opts.GetType() == rpc.IndexOptions_DEFAULT, opts.GetType() == rpc.IndexOptions_JSON:
+		g.P(`        metadata["`, name, `"] = `, value)
+
+	case field.Desc.IsList() || isMessage(field) || kind == protoreflect.BytesKind:
+		return fmt.Errorf("%s: type %s is not supported for %s fields", field.Desc.FullName(), opts.GetType(), kind)
+
+	case opts.GetType() == rpc.IndexOptions_TEXT:
+		if kind == protoreflect.StringKind {
+			g.P(`        metadata["`, name, `"] = `, value)
+		} else {
+			g.P(`        metadata["`, name, `"] = `, fmtSprint, "(", value, ")")
+		}
+
+	case opts.GetType() == rpc.IndexOptions_INTEGER && kind == protoreflect.StringKind:
+		g.P("        if v, err := ", strconvInt, "(", value, ", 10, 64); err == nil {")
+		g.P(`            metadata["`, name, `"] = v`)
+		g.P("        }")
+
+	case opts.GetType() == rpc.IndexOptions_REAL && kind == protoreflect.StringKind:
+		g.P("        if v, err := ", strconvFloat, "(", value, ", 64); err == nil {")
+		g.P(`            metadata["`, name, `"] = v`)
+		g.P("        }")
+
+	case opts.GetType() == rpc.IndexOptions_TIMESTAMP && kind == protoreflect.StringKind:
+		g.P("        for _, layout := range []string{", timeRFC3339, ", ", timeDateTime, ", ", timeDateOnly, "} {")
+		g.P("            if t, err := ", timeParse, "(layout, ", value, "); err == nil {")
+		g.P(`                metadata["`, name, `"] = t.UnixMilli()`)
+		g.P("                break")
+		g.P("            }")
+		g.P("        }")
+
+	case opts.GetType() == rpc.IndexOptions_TIMESTAMP && isInteger(kind):
+		g.P(`        metadata["`, name, `"] = int64(`, value, ") * 1000")
+
+	case (opts.GetType() == rpc.IndexOptions_INTEGER || opts.GetType() == rpc.IndexOptions_REAL) && isInteger(kind),
+		opts.GetType() == rpc.IndexOptions_REAL && (kind == protoreflect.FloatKind || kind == protoreflect.DoubleKind):
+		g.P(`        metadata["`, name, `"] = `, value)
+
+	default:
+		return fmt.Errorf("%s: type %s is not supported for %s fields", field.Desc.FullName(), opts.GetType(), kind)
+	}
+
+	return nil
+}
+
+// generateKey generates SchemaKey for messages with unique_key fields
+func generateKey(g *protogen.GeneratedFile, message *protogen.Message) error {
+	var keys []*protogen.Field
+	for _, field := range message.Fields {
+		if !indexOptions(field).GetUniqueKey() {
+			continue
+		}
+		if field.Desc.IsList() || isMessage(field) {
+			return fmt.Errorf("%s: unique_key is only supported for scalar fields", field.Desc.FullName())
+		}
+		keys = append(keys, field)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(keys))
+	for n, field := range keys {
+		if field.Desc.Kind() == protoreflect.StringKind {
+			values[n] = "m.Get" + field.GoName + "()"
+		} else {
+			values[n] = g.QualifiedGoIdent(fmtSprint) + "(m.Get" + field.GoName + "())"
+		}
+	}
+
+	g.P("func (m *", message.GoIdent.GoName, ") SchemaKey() string {")
+	if len(values) == 1 {
+		g.P("    return ", values[0])
+	} else {
+		g.P("    return ", strings.Join(values, ` + "/" + `))
+	}
 	g.P("}")
 	g.P()
+
+	return nil
+}
+
+// hasFulltext reports whether message or the messages flattened into it have
+// fulltext fields
+func hasFulltext(message *protogen.Message, seen map[*protogen.Message]bool) bool {
+	seen[message] = true
+	for _, field := range message.Fields {
+		opts := indexOptions(field)
+		switch {
+		case opts.GetSkip():
+		case opts.GetFulltext():
+			return true
+		case opts.GetType() == rpc.IndexOptions_DEFAULT && flatten(field, seen):
+			if hasFulltext(field.Message, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateOptions(field *protogen.Field) *rpc.ValidateOptions {
+	opts, _ := proto.GetExtension(field.Desc.Options(), rpc.E_Validate).(*rpc.ValidateOptions)
+	return opts
+}
+
+func indexOptions(field *protogen.Field) *rpc.IndexOptions {
+	opts, _ := proto.GetExtension(field.Desc.Options(), rpc.E_Index).(*rpc.IndexOptions)
+	return opts
+}
+
+func isMessage(field *protogen.Field) bool {
+	return field.Message != nil && !field.Desc.IsMap()
+}
+
+// flatten reports whether the fields of a message field are indexed with
+// dotted names. Repeated and recursive messages are indexed as JSON.
+func flatten(field *protogen.Field, seen map[*protogen.Message]bool) bool {
+	return isMessage(field) && !field.Desc.IsList() && !isTimestamp(field) && !seen[field.Message]
+}
+
+func isTimestamp(field *protogen.Field) bool {
+	return !field.Desc.IsList() && field.Message != nil &&
+		field.Message.Desc.FullName() == "google.protobuf.Timestamp"
+}
+
+func isInteger(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind,
+		protoreflect.Sint64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed32Kind, protoreflect.Fixed64Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Sfixed64Kind:
+		return true
+	}
+	return false
 }
 
 func zeroValue(field *protogen.Field) string {
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"go.quinn.io/dataq/boot"
+	"go.quinn.io/dataq/query"
+)
+
+// Usage: query 'kind:Email from:"alice@" date>2024-01-01 sort:-date'
+func main() {
+	b, err := boot.New()
+	if err != nil {
+		log.Fatalf("Failed to initialize boot: %v", err)
+	}
+
+	result, err := query.Run(context.Background(), b.Index, strings.Join(os.Args[1:], " "))
+	if err != nil {
+		log.Fatalf("Failed to run query: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for _, row := range result.Rows {
+		if err := enc.Encode(row); err != nil {
+			log.Fatalf("Failed to encode row: %v", err)
+		}
+	}
+}
@@ -0,0 +1,555 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	sq "github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.quinn.io/dataq/canonical"
+	"go.quinn.io/dataq/cas"
+	"go.quinn.io/dataq/index"
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+)
+
+// unknownKind is a kind no plugin declares
+const unknownKind = "dataq-conformance-unknown-kind"
+
+type suite struct {
+	client rpc.DataQPluginClient
+	opts   Options
+	cas    cas.Storage
+	index  *index.Index
+	report *Report
+
+	install *rpc.InstallResponse
+
+	// instance is the address of the PluginInstance requests are sent for
+	instance string
+
+	// permanodes is the content of the permanodes already checked
+	permanodes map[string]bool
+}
+
+func (s *suite) check(name string, err error) {
+	s.report.Results = append(s.report.Results, Result{Name: name, Err: err})
+}
+
+func (s *suite) run(ctx context.Context) {
+	// The other checks need the declared kinds, even if some are malformed
+	s.check("install", s.checkInstall(ctx))
+	if s.install == nil {
+		return
+	}
+
+	s.check("unknown extract kind", s.checkUnknownExtract(ctx))
+	s.check("unknown streamed extract kind", s.checkUnknownExtractStream(ctx))
+	s.check("unknown transform kind", s.checkUnknownTransform(ctx))
+
+	samples := make(map[string]*rpc.ExtractRequest)
+	for _, req := range s.opts.Extracts {
+		samples[req.Kind] = req
+	}
+
+	// Transforms of the extracted content are checked with the samples
+	transforms := s.opts.Transforms
+	extracted := make(map[string]bool)
+	for _, e := range s.install.GetExtracts() {
+		if extracted[e.Kind] {
+			continue
+		}
+		extracted[e.Kind] = true
+
+		req, ok := samples[e.Kind]
+		if !ok {
+			req = &rpc.ExtractRequest{Kind: e.Kind}
+		}
+
+		res, err := s.extract(ctx, req)
+		s.check("extract "+e.Kind, err)
+		transforms = append(transforms, res...)
+	}
+
+	sampled := make(map[string]bool)
+	for _, req := range transforms {
+		sampled[req.Kind] = true
+		s.check("transform "+req.Kind, s.transform(ctx, req))
+	}
+
+	// Declared kinds without content to transform must at least be accepted
+	for _, t := range s.install.GetTransforms() {
+		if !sampled[t.Kind] {
+			s.check("transform "+t.Kind+" accepted", s.checkTransformAccepted(ctx, t.Kind))
+		}
+	}
+}
+
+// checkInstall calls Install and checks its response is well-formed.
+func (s *suite) checkInstall(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	res, err := s.client.Install(ctx, &rpc.InstallRequest{PluginId: s.opts.PluginID})
+	if err != nil {
+		return fmt.Errorf("failed to install: %w", err)
+	}
+	s.install = res
+
+	// Requests are sent for an instance of the plugin, like the host does
+	s.instance, err = s.index.CreatePermanode(ctx, &schema.PluginInstance{
+		PluginID:        res.PluginId,
+		Label:           res.PluginId,
+		InstallResponse: res,
+	})
+	if err != nil {
+		s.install = nil
+		return fmt.Errorf("failed to create plugin instance: %w", err)
+	}
+
+	var errs []error
+	if res.PluginId == "" {
+		errs = append(errs, fmt.Errorf("plugin_id is empty"))
+	} else if s.opts.PluginID != "" && res.PluginId != s.opts.PluginID {
+		errs = append(errs, fmt.Errorf("plugin_id is %q, expected %q", res.PluginId, s.opts.PluginID))
+	}
+
+	errs = append(errs, checkConfigs("plugin", res.Configs)...)
+
+	if res.Oauth != nil {
+		endpoint := res.Oauth.GetConfig().GetEndpoint()
+		for name, u := range map[string]string{"auth_url": endpoint.GetAuthUrl(), "token_url": endpoint.GetTokenUrl()} {
+			if parsed, err := url.Parse(u); err != nil || !parsed.IsAbs() {
+				errs = append(errs, fmt.Errorf("oauth %s is not an absolute URL: %q", name, u))
+			}
+		}
+		if res.Oauth.Token != nil {
+			errs = append(errs, fmt.Errorf("oauth must not include a token"))
+		}
+	}
+
+	extracts := make(map[string]bool)
+	for _, e := range res.Extracts {
+		switch {
+		case e.Kind == "":
+			errs = append(errs, fmt.Errorf("extract kind is empty"))
+		case extracts[e.Kind]:
+			errs = append(errs, fmt.Errorf("extract %s is declared twice", e.Kind))
+		}
+		extracts[e.Kind] = true
+
+		if e.Label == "" {
+			errs = append(errs, fmt.Errorf("extract %s has no label", e.Kind))
+		}
+		errs = append(errs, checkConfigs("extract "+e.Kind, e.Configs)...)
+		if err := checkFreshness(e.Freshness); err != nil {
+			errs = append(errs, fmt.Errorf("extract %s: %w", e.Kind, err))
+		}
+	}
+
+	transforms := make(map[string]bool)
+	for _, t := range res.Transforms {
+		switch {
+		case t.Kind == "":
+			errs = append(errs, fmt.Errorf("transform kind is empty"))
+		case transforms[t.Kind]:
+			errs = append(errs, fmt.Errorf("transform %s is declared twice", t.Kind))
+		}
+		transforms[t.Kind] = true
+
+		if err := checkFreshness(t.Freshness); err != nil {
+			errs = append(errs, fmt.Errorf("transform %s: %w", t.Kind, err))
+		}
+	}
+
+	// The host registers the descriptors to index Any payloads
+	if res.Descriptors != nil {
+		if _, err := protodesc.NewFiles(res.Descriptors); err != nil {
+			errs = append(errs, fmt.Errorf("invalid descriptors: %w", err))
+		} else if _, err := s.index.StoreDescriptors(ctx, res.Descriptors); err != nil {
+			errs = append(errs, fmt.Errorf("failed to store descriptors: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkConfigs(name string, configs []*rpc.PluginConfig) []error {
+	var errs []error
+	keys := make(map[string]bool)
+	for _, c := range configs {
+		switch {
+		case c.Key == "":
+			errs = append(errs, fmt.Errorf("%s has a config without a key", name))
+		case keys[c.Key]:
+			errs = append(errs, fmt.Errorf("%s declares config %s twice", name, c.Key))
+		}
+		keys[c.Key] = true
+	}
+	return errs
+}
+
+func checkFreshness(f *rpc.Freshness) error {
+	switch f.GetPolicy() {
+	case rpc.Freshness_TTL:
+		if f.GetTtl() == nil {
+			return fmt.Errorf("TTL freshness without a ttl")
+		}
+		if err := f.GetTtl().CheckValid(); err != nil {
+			return fmt.Errorf("invalid ttl: %w", err)
+		}
+		if f.GetTtl().AsDuration() <= 0 {
+			return fmt.Errorf("ttl must be positive")
+		}
+	case rpc.Freshness_ALWAYS, rpc.Freshness_NEVER:
+		if f.GetTtl() != nil {
+			return fmt.Errorf("ttl set on %s freshness", f.GetPolicy())
+		}
+	default:
+		return fmt.Errorf("unknown freshness policy %d", f.GetPolicy())
+	}
+	return nil
+}
+
+func (s *suite) checkUnknownExtract(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	_, err := s.client.Extract(ctx, &rpc.ExtractRequest{InstanceHash: s.instance, Kind: unknownKind})
+	return expectUnimplemented(err)
+}
+
+func (s *suite) checkUnknownExtractStream(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	stream, err := s.client.ExtractStream(ctx, &rpc.ExtractRequest{InstanceHash: s.instance, Kind: unknownKind})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	return expectUnimplemented(err)
+}
+
+func (s *suite) checkUnknownTransform(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	_, err := s.client.Transform(ctx, &rpc.TransformRequest{
+		InstanceHash: s.instance,
+		Kind:         unknownKind,
+		Data:         &rpc.TransformRequest_Content{Content: []byte("{}")},
+	})
+	return expectUnimplemented(err)
+}
+
+func expectUnimplemented(err error) error {
+	if err == nil {
+		return fmt.Errorf("unknown kind was accepted")
+	}
+	if code := status.Code(err); code != codes.Unimplemented {
+		return fmt.Errorf("unknown kind returned %s, expected %s: %w", code, codes.Unimplemented, err)
+	}
+	return nil
+}
+
+// accepted returns an error if the plugin rejected a declared kind as unknown.
+func accepted(kind string, err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("declared kind %s is not implemented: %w", kind, err)
+	}
+	return nil
+}
+
+// extract calls a declared extract kind like the host does and checks its
+// response round-trips through the index. It returns the transform requests
+// the host would create.
+func (s *suite) extract(ctx context.Context, req *rpc.ExtractRequest) ([]*rpc.TransformRequest, error) {
+	req = proto.Clone(req).(*rpc.ExtractRequest)
+	req.InstanceHash = s.instance
+
+	// The token is attached when the request is sent, never stored
+	oauth := req.Oauth
+	req.Oauth = nil
+	requestHash, err := s.index.Store(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store request: %w", err)
+	}
+	req.Oauth = oauth
+
+	callCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	if s.streamed(req.Kind) {
+		return s.extractStream(callCtx, req, requestHash)
+	}
+
+	res, err := s.client.Extract(callCtx, req)
+	if err != nil {
+		// Extracts usually need credentials or metadata the suite doesn't
+		// have, a declared kind only has to be recognized
+		return nil, accepted(req.Kind, err)
+	}
+
+	return s.storeResponse(ctx, req, requestHash, res)
+}
+
+// maxStreamItems is the number of items of a stream the suite checks before
+// it cancels the stream
+const maxStreamItems = 20
+
+// extractStream calls a streamed extract kind and checks its first items like
+// extract checks a response.
+func (s *suite) extractStream(ctx context.Context, req *rpc.ExtractRequest, requestHash string) ([]*rpc.TransformRequest, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.ExtractStream(ctx, req)
+	if err != nil {
+		return nil, accepted(req.Kind, err)
+	}
+
+	var transforms []*rpc.TransformRequest
+	for n := 0; n < maxStreamItems; n++ {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if n == 0 {
+				return nil, accepted(req.Kind, err)
+			}
+			return nil, fmt.Errorf("failed to receive item %d: %w", n, err)
+		}
+
+		t, err := s.storeResponse(ctx, req, requestHash, res)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", n, err)
+		}
+		transforms = append(transforms, t...)
+	}
+
+	return transforms, nil
+}
+
+// storeResponse checks a response to the request at requestHash round-trips
+// through the index, and returns the transform requests the host would create.
+func (s *suite) storeResponse(ctx context.Context, req *rpc.ExtractRequest, requestHash string, res *rpc.ExtractResponse) ([]*rpc.TransformRequest, error) {
+	content := res.GetContent()
+	if content == nil {
+		return nil, fmt.Errorf("response content is nil")
+	}
+
+	dataHash, err := s.cas.Store(ctx, bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store response content: %w", err)
+	}
+
+	res.RequestHash = requestHash
+	res.ReceivedAt = timestamppb.Now()
+	res.Data = &rpc.ExtractResponse_Hash{Hash: dataHash}
+	if err := s.roundTrip(ctx, res, new(rpc.ExtractResponse)); err != nil {
+		return nil, err
+	}
+
+	var transforms []*rpc.TransformRequest
+	for _, t := range res.Transforms {
+		transforms = append(transforms, &rpc.TransformRequest{
+			InstanceHash: req.InstanceHash,
+			Data:         &rpc.TransformRequest_Content{Content: content},
+			DataHash:     dataHash,
+			Kind:         t.Kind,
+			Metadata:     t.Metadata,
+		})
+	}
+
+	return transforms, nil
+}
+
+func (s *suite) checkTransformAccepted(ctx context.Context, kind string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	_, err := s.client.Transform(ctx, &rpc.TransformRequest{
+		InstanceHash: s.instance,
+		Kind:         kind,
+		Data:         &rpc.TransformRequest_Content{Content: []byte("{}")},
+	})
+	return accepted(kind, err)
+}
+
+// transform calls a transform twice with the same content, checks the
+// responses are identical and round-trip through the index, and that the
+// permanodes can be stored.
+func (s *suite) transform(ctx context.Context, req *rpc.TransformRequest) error {
+	req = proto.Clone(req).(*rpc.TransformRequest)
+	req.InstanceHash = s.instance
+
+	content := req.GetContent()
+	if req.DataHash == "" {
+		var err error
+		if req.DataHash, err = s.cas.Store(ctx, bytes.NewReader(content)); err != nil {
+			return fmt.Errorf("failed to store content: %w", err)
+		}
+	}
+
+	// The host stores the request with the address of the content
+	stored := proto.Clone(req).(*rpc.TransformRequest)
+	stored.Data = &rpc.TransformRequest_Hash{Hash: req.DataHash}
+	stored.DataHash = ""
+	requestHash, err := s.index.Store(ctx, stored)
+	if err != nil {
+		return fmt.Errorf("failed to store request: %w", err)
+	}
+
+	var responses [2][]byte
+	var res *rpc.TransformResponse
+	for n := range responses {
+		callCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+		res, err = s.client.Transform(callCtx, req)
+		cancel()
+		if err != nil {
+			if err := accepted(req.Kind, err); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed to transform: %w", err)
+		}
+
+		if responses[n], err = canonical.Marshal(res); err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+	}
+	if !bytes.Equal(responses[0], responses[1]) {
+		return fmt.Errorf("transform is not deterministic:\n%s\n%s", responses[0], responses[1])
+	}
+
+	var errs []error
+	for _, e := range res.Extracts {
+		if !s.declaresExtract(e.Kind) {
+			errs = append(errs, fmt.Errorf("returns undeclared extract kind %s", e.Kind))
+		}
+	}
+
+	res.RequestHash = requestHash
+	res.ReceivedAt = timestamppb.Now()
+	if err := s.roundTrip(ctx, res, new(rpc.TransformResponse)); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, permanode := range res.Permanodes {
+		if err := s.permanode(ctx, permanode); err != nil {
+			errs = append(errs, fmt.Errorf("permanode %s %q: %w", permanode.Kind, permanode.Key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// streamed reports whether an extract kind is declared as streamed.
+func (s *suite) streamed(kind string) bool {
+	for _, e := range s.install.GetExtracts() {
+		if e.Kind == kind {
+			return e.Stream
+		}
+	}
+	return false
+}
+
+func (s *suite) declaresExtract(kind string) bool {
+	for _, e := range s.install.GetExtracts() {
+		if e.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// roundTrip stores res in the index and checks it reads back unchanged.
+func (s *suite) roundTrip(ctx context.Context, res, result index.IndexableProto) error {
+	hash, err := s.index.Store(ctx, res)
+	if err != nil {
+		return fmt.Errorf("failed to store %s: %w", res.SchemaKind(), err)
+	}
+
+	if err := s.index.Get(ctx, result, s.index.Q.Where(sq.Eq{"content_hash": hash})); err != nil {
+		return fmt.Errorf("failed to read %s: %w", res.SchemaKind(), err)
+	}
+
+	if !proto.Equal(res, result) {
+		return fmt.Errorf("%s changed when stored:\n%v\n%v", res.SchemaKind(), res, result)
+	}
+
+	return nil
+}
+
+// permanode checks a permanode is valid and its content reads back from the
+// index unchanged.
+func (s *suite) permanode(ctx context.Context, permanode *rpc.TransformResponse_Permanode) error {
+	content, err := index.PermanodeContent(permanode)
+	if err != nil {
+		return err
+	}
+
+	key := permanode.Key
+	if keyed, ok := content.(index.Keyed); ok && key == "" {
+		key = keyed.SchemaKey()
+	}
+	if key == "" {
+		return fmt.Errorf("no key")
+	}
+
+	if v, ok := content.(index.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("invalid content: %w", err)
+		}
+	}
+
+	want, err := canonical.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content: %w", err)
+	}
+
+	// Content is only indexed once, a second permanode would have no version
+	if s.permanodes[string(want)] {
+		return nil
+	}
+	s.permanodes[string(want)] = true
+
+	permanodeHash, err := s.index.CreatePermanode(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to store permanode: %w", err)
+	}
+
+	claims, err := s.index.Query(ctx, s.index.Q.
+		Where(sq.Eq{"permanode_hash": permanodeHash}).
+		Where(sq.NotEq{"content_hash": ""}))
+	if err != nil {
+		return fmt.Errorf("failed to query permanode: %w", err)
+	}
+	if len(claims) != 1 {
+		return fmt.Errorf("expected 1 version of the permanode, found %d", len(claims))
+	}
+
+	stored, err := s.index.UnmarshalContent(ctx, claims[0], claims[0].ContentHash)
+	if err != nil {
+		return fmt.Errorf("failed to read permanode: %w", err)
+	}
+
+	got, err := canonical.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stored content: %w", err)
+	}
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("content changed when stored:\n%s\n%s", want, got)
+	}
+
+	return nil
+}
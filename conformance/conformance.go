@@ -0,0 +1,204 @@
+// Package conformance checks that a plugin behaves the way the host expects,
+// without running the host. It calls the plugin like the host does and stores
+// the responses in an index kept in memory:
+//
+//   - Install is well-formed
+//   - every declared extract and transform kind is accepted, streamed kinds
+//     with ExtractStream
+//   - unknown kinds are rejected with codes.Unimplemented
+//   - transforms return the same response for the same input
+//   - responses and permanodes read back from the index as they were stored
+//
+// Extracts are called with the requests in Options, or with empty metadata.
+// Responses of extracts that succeed are transformed like the host would. Only
+// the first items of a streamed extract are checked.
+package conformance
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"go.quinn.io/dataq/cas"
+	"go.quinn.io/dataq/handshake"
+	"go.quinn.io/dataq/index"
+	"go.quinn.io/dataq/rpc"
+)
+
+// Options configures the requests the suite makes
+type Options struct {
+	// PluginID is the id Install must return, any id is accepted if empty
+	PluginID string
+
+	// Extracts are requests for declared extract kinds, e.g. with the
+	// metadata and OAuth token a kind needs. Kinds without a request are
+	// called with empty metadata.
+	Extracts []*rpc.ExtractRequest
+
+	// Transforms are requests with sample content for transform kinds
+	Transforms []*rpc.TransformRequest
+
+	// Timeout limits each call to the plugin, 30 seconds if zero
+	Timeout time.Duration
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Name string
+	Err  error
+}
+
+// Report is the outcome of the suite
+type Report struct {
+	Results []Result
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes a line for each check.
+func (r *Report) Print(w io.Writer) {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			fmt.Fprintf(w, "FAIL %s: %v\n", res.Name, res.Err)
+		} else {
+			fmt.Fprintf(w, "ok   %s\n", res.Name)
+		}
+	}
+}
+
+// defaultTimeout limits calls to the plugin when Options.Timeout is zero
+const defaultTimeout = 30 * time.Second
+
+// Run runs the suite against a connected plugin.
+func Run(ctx context.Context, client rpc.DataQPluginClient, opts Options) (*Report, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultTimeout
+	}
+
+	// A single connection, each connection to :memory: is a new database
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	store := cas.NewMemory()
+	s := &suite{
+		client: client,
+		opts:   opts,
+		cas:    store,
+		index:  index.NewIndex(store, db),
+		report: &Report{},
+
+		permanodes: make(map[string]bool),
+	}
+	s.run(ctx)
+
+	return s.report, nil
+}
+
+// RunServer runs the suite against a plugin server in this process, over an
+// in-memory gRPC connection.
+func RunServer(ctx context.Context, srv rpc.DataQPluginServer, opts Options) (*Report, error) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	rpc.RegisterDataQPluginServer(s, srv)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(handshake.MaxMessageSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin: %w", err)
+	}
+	defer conn.Close()
+
+	return Run(ctx, rpc.NewDataQPluginClient(conn), opts)
+}
+
+// handshakeTimeout is how long a plugin binary has to print its handshake
+const handshakeTimeout = 10 * time.Second
+
+// RunBinary starts a plugin binary like the host does and runs the suite
+// against it. The plugin's output is copied to stderr.
+func RunBinary(ctx context.Context, path string, opts Options) (*Report, error) {
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), handshake.Env("")...)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin process: %w", err)
+	}
+	defer func() {
+		cmd.Process.Signal(syscall.SIGTERM)
+		cmd.Wait()
+	}()
+
+	conn, err := connect(ctx, stdout)
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+	defer conn.Close()
+
+	return Run(ctx, rpc.NewDataQPluginClient(conn), opts)
+}
+
+// connect reads the handshake from a plugin's stdout and connects to it.
+func connect(ctx context.Context, stdout io.Reader) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+	defer cancel()
+
+	type result struct {
+		addr handshake.Address
+		err  error
+	}
+	read := make(chan result, 1)
+	r := bufio.NewReader(stdout)
+	go func() {
+		addr, err := handshake.Read(r, os.Stderr)
+		read <- result{addr, err}
+		if err == nil {
+			io.Copy(os.Stderr, r)
+		}
+	}()
+
+	select {
+	case res := <-read:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return handshake.Dial(ctx, res.addr)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no handshake after %s", handshakeTimeout)
+	}
+}
@@ -0,0 +1,265 @@
+// Package fixture records the responses of plugin extracts to a directory and
+// replays them, so transforms and the index can be developed offline and
+// reproducibly, without OAuth credentials.
+//
+// The host records when EnvRecord is set to a directory and replays when
+// EnvReplay is. Each extract is stored in <dir>/<plugin id>/ as
+// <kind>-<key>.json, with the request and the response without its content,
+// and the content as it was extracted in <kind>-<key>.data. The items of a
+// streamed extract are numbered, <kind>-<key>.<n>.json. Requests match a
+// fixture when they are for the same plugin, with the same kind and metadata,
+// whichever instance of the plugin they are for.
+package fixture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.quinn.io/dataq/canonical"
+	"go.quinn.io/dataq/rpc"
+)
+
+const (
+	// EnvRecord is the directory extract responses are recorded to
+	EnvRecord = "DATAQ_RECORD"
+
+	// EnvReplay is the directory extract responses are replayed from. Plugins
+	// are not called for extracts while replaying.
+	EnvReplay = "DATAQ_REPLAY"
+)
+
+// Mode is whether fixtures are recorded or replayed
+type Mode string
+
+const (
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+// Fixtures is a directory of recorded extracts. A nil *Fixtures neither
+// records nor replays.
+type Fixtures struct {
+	Dir  string
+	Mode Mode
+}
+
+// FromEnv returns the fixtures configured in the environment, nil if neither
+// EnvRecord nor EnvReplay is set.
+func FromEnv() (*Fixtures, error) {
+	record, replay := os.Getenv(EnvRecord), os.Getenv(EnvReplay)
+	switch {
+	case record != "" && replay != "":
+		return nil, fmt.Errorf("only one of %s and %s can be set", EnvRecord, EnvReplay)
+	case record != "":
+		return &Fixtures{Dir: record, Mode: Record}, nil
+	case replay != "":
+		return &Fixtures{Dir: replay, Mode: Replay}, nil
+	}
+	return nil, nil
+}
+
+// Recording reports whether extracts are recorded.
+func (f *Fixtures) Recording() bool {
+	return f != nil && f.Mode == Record
+}
+
+// Replaying reports whether extracts are replayed.
+func (f *Fixtures) Replaying() bool {
+	return f != nil && f.Mode == Replay
+}
+
+// fixture is the JSON file of a recorded extract
+type fixture struct {
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Save records the response of plugin pluginID to req. The OAuth token of the
+// request is never recorded.
+func (f *Fixtures) Save(pluginID string, req *rpc.ExtractRequest, res *rpc.ExtractResponse) error {
+	if !f.Recording() {
+		return nil
+	}
+
+	req = matchable(req)
+	path, err := f.path(pluginID, req)
+	if err != nil {
+		return err
+	}
+	return write(path, req, res)
+}
+
+// SaveItem records item n of the stream plugin pluginID sent for req. The items
+// recorded for req before are removed with the first one.
+func (f *Fixtures) SaveItem(pluginID string, req *rpc.ExtractRequest, n int, res *rpc.ExtractResponse) error {
+	if !f.Recording() {
+		return nil
+	}
+
+	req = matchable(req)
+	path, err := f.path(pluginID, req)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		stale, err := filepath.Glob(path + ".[0-9]*")
+		if err != nil {
+			return fmt.Errorf("failed to list fixture items: %w", err)
+		}
+		for _, name := range stale {
+			if err := os.Remove(name); err != nil {
+				return fmt.Errorf("failed to remove fixture item: %w", err)
+			}
+		}
+	}
+
+	return write(fmt.Sprintf("%s.%d", path, n), req, res)
+}
+
+// write writes the fixture of a response to path with the .json and .data
+// extensions.
+func write(path string, req *rpc.ExtractRequest, res *rpc.ExtractResponse) error {
+	res = proto.Clone(res).(*rpc.ExtractResponse)
+	content := res.GetContent()
+	res.Data = nil
+
+	var fx fixture
+	var err error
+	marshal := protojson.MarshalOptions{Multiline: true, UseProtoNames: true}
+	if fx.Request, err = marshal.Marshal(req); err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	if fx.Response, err = marshal.Marshal(res); err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	b, err := json.MarshalIndent(fx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path+".data", content, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture content: %w", err)
+	}
+	if err := os.WriteFile(path+".json", b, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+
+	return nil
+}
+
+// Load returns the response of plugin pluginID recorded for req.
+func (f *Fixtures) Load(pluginID string, req *rpc.ExtractRequest) (*rpc.ExtractResponse, error) {
+	path, err := f.path(pluginID, matchable(req))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no fixture for %s %s in %s", pluginID, req.Kind, f.Dir)
+	}
+	return res, err
+}
+
+// LoadStream yields the items of the stream plugin pluginID sent for req, in
+// the order they were recorded.
+func (f *Fixtures) LoadStream(pluginID string, req *rpc.ExtractRequest) iter.Seq2[*rpc.ExtractResponse, error] {
+	return func(yield func(*rpc.ExtractResponse, error) bool) {
+		path, err := f.path(pluginID, matchable(req))
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for n := 0; ; n++ {
+			res, err := read(fmt.Sprintf("%s.%d", path, n))
+			if errors.Is(err, os.ErrNotExist) {
+				if n == 0 {
+					yield(nil, fmt.Errorf("no fixture for %s %s in %s", pluginID, req.Kind, f.Dir))
+				}
+				return
+			}
+			if !yield(res, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// read reads the fixture of a response written to path.
+func read(path string) (*rpc.ExtractResponse, error) {
+	b, err := os.ReadFile(path + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fx fixture
+	if err := json.Unmarshal(b, &fx); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	res := new(rpc.ExtractResponse)
+	if err := protojson.Unmarshal(fx.Response, res); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture response %s: %w", path, err)
+	}
+
+	content, err := os.ReadFile(path + ".data")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture content: %w", err)
+	}
+	res.Data = &rpc.ExtractResponse_Content{Content: content}
+
+	return res, nil
+}
+
+// matchable returns req without the fields that differ between runs: the
+// token, the state, the parent, whose hash depends on when it was received,
+// and the plugin instance.
+func matchable(req *rpc.ExtractRequest) *rpc.ExtractRequest {
+	return &rpc.ExtractRequest{
+		Kind:     req.Kind,
+		Metadata: req.Metadata,
+	}
+}
+
+// path returns the path of the fixture of plugin pluginID for req without its
+// extension.
+func (f *Fixtures) path(pluginID string, req *rpc.ExtractRequest) (string, error) {
+	b, err := canonical.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+	sum := sha256.Sum256(b)
+
+	name := fmt.Sprintf("%s-%s", safe(req.Kind), hex.EncodeToString(sum[:8]))
+	return filepath.Join(f.Dir, safe(pluginID), name), nil
+}
+
+// safe replaces the characters of s that can't be used in a file name.
+func safe(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+	if s == "" {
+		return "_"
+	}
+	return s
+}
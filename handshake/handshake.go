@@ -0,0 +1,189 @@
+// Package handshake is how the host finds out where a plugin process listens.
+//
+// The host starts the plugin with EnvProtocol set, and EnvSocket set to a path
+// in the plugin's state directory where Unix sockets are supported. The plugin
+// listens on that socket, or on a port assigned by the OS, and prints a single
+// line to stdout:
+//
+//	dataq-plugin|1|unix|/home/alice/.config/dataq/state/gmail/plugin.sock
+//
+// with the protocol version, network and address. The host waits for the line,
+// connects and runs a gRPC health check before it sends the plugin requests.
+//
+// The host also serves DataQHost to the plugin, at the address in
+// EnvHostAddress. Plugins connect with DialHost, which sends the token in
+// EnvHostToken with every call.
+package handshake
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ProtocolVersion is incremented when the handshake changes incompatibly
+const ProtocolVersion = 1
+
+const (
+	// EnvProtocol is set by the host to the protocol version it speaks
+	EnvProtocol = "DATAQ_PLUGIN_PROTOCOL"
+
+	// EnvSocket is the path of the Unix socket the plugin listens on. Plugins
+	// listen on a localhost port assigned by the OS when it is not set.
+	EnvSocket = "DATAQ_PLUGIN_SOCKET"
+)
+
+// prefix starts the handshake line
+const prefix = "dataq-plugin"
+
+// Address is where a plugin listens
+type Address struct {
+	Network string
+	Address string
+}
+
+// Env returns the environment the host starts a plugin with. socket is empty
+// if the plugin should listen on a TCP port.
+func Env(socket string) []string {
+	env := []string{fmt.Sprintf("%s=%d", EnvProtocol, ProtocolVersion)}
+	if socket != "" {
+		env = append(env, EnvSocket+"="+socket)
+	}
+	return env
+}
+
+// Listen listens where the host asked the plugin to.
+func Listen() (net.Listener, error) {
+	if socket := os.Getenv(EnvSocket); socket != "" {
+		// A previous process may have left the socket behind
+		if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+		return net.Listen("unix", socket)
+	}
+
+	return net.Listen("tcp", "127.0.0.1:0")
+}
+
+// Line returns the handshake line announcing lis.
+func Line(lis net.Listener) string {
+	addr := lis.Addr()
+	return fmt.Sprintf("%s|%d|%s|%s\n", prefix, ProtocolVersion, addr.Network(), addr.String())
+}
+
+// Serve listens, announces the address on stdout and serves s until it stops.
+// A health service reporting SERVING is registered on s.
+func Serve(s *grpc.Server) error {
+	lis, err := Listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(s, healthServer)
+
+	if _, err := io.WriteString(os.Stdout, Line(lis)); err != nil {
+		return fmt.Errorf("failed to write handshake: %w", err)
+	}
+
+	return s.Serve(lis)
+}
+
+// Read reads lines from a plugin's stdout until the handshake line. Lines
+// before it are copied to w.
+func Read(r *bufio.Reader, w io.Writer) (Address, error) {
+	for {
+		line, err := r.ReadString('\n')
+		if strings.HasPrefix(line, prefix+"|") {
+			return parse(strings.TrimSpace(line))
+		}
+		if line != "" {
+			io.WriteString(w, line)
+		}
+		if err == io.EOF {
+			return Address{}, fmt.Errorf("plugin exited before the handshake")
+		}
+		if err != nil {
+			return Address{}, fmt.Errorf("failed to read handshake: %w", err)
+		}
+	}
+}
+
+func parse(line string) (Address, error) {
+	parts := strings.SplitN(line, "|", 4)
+	if len(parts) != 4 {
+		return Address{}, fmt.Errorf("malformed handshake: %q", line)
+	}
+
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Address{}, fmt.Errorf("malformed handshake version: %q", line)
+	}
+	if version != ProtocolVersion {
+		return Address{}, fmt.Errorf("unsupported handshake version %d, expected %d", version, ProtocolVersion)
+	}
+
+	switch parts[2] {
+	case "unix", "tcp":
+	default:
+		return Address{}, fmt.Errorf("unsupported handshake network: %q", parts[2])
+	}
+
+	return Address{Network: parts[2], Address: parts[3]}, nil
+}
+
+// target is the gRPC dial target of addr
+func (addr Address) target() string {
+	if addr.Network == "unix" {
+		return "unix://" + addr.Address
+	}
+	return "passthrough:///" + addr.Address
+}
+
+// MaxMessageSize is the largest response the host receives from a plugin.
+// Extracted content is sent whole, e.g. attachments and files.
+const MaxMessageSize = 64 << 20
+
+// healthInterval is the delay between health checks while a plugin starts
+const healthInterval = 50 * time.Millisecond
+
+// Dial connects to a plugin and waits until its health check reports SERVING
+// or ctx is done.
+func Dial(ctx context.Context, addr Address) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(addr.target(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxMessageSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin: %w", err)
+	}
+
+	client := grpc_health_v1.NewHealthClient(conn)
+	for {
+		res, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err == nil && res.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING {
+			return conn, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			if err == nil {
+				err = fmt.Errorf("status %s", res.GetStatus())
+			}
+			return nil, fmt.Errorf("plugin health check failed: %w", err)
+		case <-time.After(healthInterval):
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package handshake
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// EnvHostAddress is where the host serves DataQHost to the plugin, as
+	// network|address
+	EnvHostAddress = "DATAQ_HOST_ADDRESS"
+
+	// EnvHostToken authenticates the plugin to the host
+	EnvHostToken = "DATAQ_HOST_TOKEN"
+)
+
+// tokenKey is the metadata key calls to the host carry the token in
+const tokenKey = "dataq-host-token"
+
+// HostEnv returns the environment telling a plugin where the host listens for
+// it and the token it must send.
+func HostEnv(lis net.Listener, token string) []string {
+	addr := lis.Addr()
+	return []string{
+		fmt.Sprintf("%s=%s|%s", EnvHostAddress, addr.Network(), addr.String()),
+		EnvHostToken + "=" + token,
+	}
+}
+
+// NewToken returns a random token for HostEnv.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Authorize returns an interceptor rejecting calls that don't carry token.
+func Authorize(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(tokenKey)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid host token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// DialHost connects a plugin to the host that started it. It fails if the
+// plugin wasn't started by a host.
+func DialHost() (*grpc.ClientConn, error) {
+	network, address, ok := strings.Cut(os.Getenv(EnvHostAddress), "|")
+	if !ok {
+		return nil, fmt.Errorf("%s is not set, the plugin was not started by a host", EnvHostAddress)
+	}
+
+	addr := Address{Network: network, Address: address}
+	conn, err := grpc.NewClient(addr.target(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials(os.Getenv(EnvHostToken))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to host: %w", err)
+	}
+
+	return conn, nil
+}
+
+// tokenCredentials sends the host token with every call. The connection is
+// local, so it is sent without transport security.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{tokenKey: string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,78 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Backend is the SQL database the index is stored in. It covers the parts of
+// the schema and queries that differ between databases, everything else is
+// shared by the Index.
+//
+// Columns starting with an underscore are internal to a backend, they are not
+// returned by Columns or included in claim metadata.
+type Backend interface {
+	// Placeholder is the bind variable format of the database
+	Placeholder() sq.PlaceholderFormat
+
+	// CreateTables creates the index_data, edges and changes tables if they
+	// don't exist
+	CreateTables(ctx context.Context, db *sql.DB) error
+
+	// Columns returns the columns of index_data, or nil if it doesn't exist
+	Columns(ctx context.Context, db *sql.DB) ([]string, error)
+
+	// AddColumn adds a metadata column to index_data with a type suitable for value
+	AddColumn(ctx context.Context, db *sql.DB, name string, value interface{}) error
+
+	// UndefinedColumn reports whether err was caused by a query referencing a
+	// column that doesn't exist
+	UndefinedColumn(err error) bool
+
+	// InsertIgnore makes insert skip rows that already exist
+	InsertIgnore(insert sq.InsertBuilder) sq.InsertBuilder
+
+	// InsertChange runs the insert of a row of changes. Changes must become
+	// visible in the order of their seq, or subscribers resuming after a seq
+	// would skip the ones committed late.
+	InsertChange(ctx context.Context, db *sql.DB, insert sq.InsertBuilder) error
+
+	// RowID is an expression for an increasing identifier of index_data rows
+	RowID() string
+
+	// Like matches a quoted column against a LIKE pattern, ignoring case.
+	// Wildcards are escaped with a backslash, see EscapeLike.
+	Like(column, pattern string) sq.Sqlizer
+
+	// Search matches rows containing text in any of the quoted columns
+	Search(text string, columns []string) sq.Sqlizer
+
+	// Extra returns internal columns to insert along with the metadata of a claim
+	Extra(metadata map[string]interface{}) (map[string]interface{}, error)
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns and the escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// EscapeLike escapes s so that it matches literally in a LIKE pattern.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// columnType returns the SQL type used for a metadata value. Complex types are
+// stored as JSON text.
+func columnType(value interface{}) string {
+	switch value.(type) {
+	case int, int32, int64:
+		return "INTEGER"
+	case float32, float64:
+		return "REAL"
+	case bool:
+		return "BOOLEAN"
+	default:
+		return "TEXT"
+	}
+}
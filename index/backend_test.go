@@ -0,0 +1,125 @@
+package index
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/rpc"
+)
+
+func TestBackend(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, idx *Index) {
+		ctx := context.Background()
+		backend := idx.Backend()
+
+		// Tables are created once per index, and again by other hosts
+		for range 2 {
+			if err := backend.CreateTables(ctx, idx.db); err != nil {
+				t.Fatalf("CreateTables: %v", err)
+			}
+		}
+
+		for _, value := range []interface{}{int64(1), 1.5, true, "text", []string{"a"}} {
+			name := columnType(value) + "_column"
+			if _, ok := value.([]string); ok {
+				name = "json_column"
+			}
+			if err := backend.AddColumn(ctx, idx.db, name, value); err != nil {
+				t.Fatalf("AddColumn(%s): %v", name, err)
+			}
+		}
+		idx.resetColumns()
+
+		columns, err := idx.Columns(ctx)
+		if err != nil {
+			t.Fatalf("Columns: %v", err)
+		}
+		for _, name := range []string{"schema_kind", "permanode_hash", "timestamp", "content_hash", "INTEGER_column", "REAL_column", "BOOLEAN_column", "TEXT_column", "json_column"} {
+			if !slices.Contains(columns, name) {
+				t.Errorf("Columns = %v, missing %s", columns, name)
+			}
+		}
+		for _, name := range columns {
+			if name[0] == '_' {
+				t.Errorf("Columns = %v, has internal column %s", columns, name)
+			}
+		}
+
+		_, err = idx.db.ExecContext(ctx, `SELECT missing FROM index_data`)
+		if err == nil || !backend.UndefinedColumn(err) {
+			t.Errorf("UndefinedColumn(%v) = false, want true", err)
+		}
+		_, err = idx.db.ExecContext(ctx, `SELECT * FROM missing_table`)
+		if err == nil || backend.UndefinedColumn(err) {
+			t.Errorf("UndefinedColumn(%v) = true, want false", err)
+		}
+
+		// Queries on columns that don't exist yet have no results
+		claims, err := idx.Query(ctx, idx.Q.Where(sq.Eq{"missing": "x"}))
+		if err != nil || len(claims) != 0 {
+			t.Errorf("Query on a missing column = %v, %v, want no results", claims, err)
+		}
+
+		edge := Edge{From: "a", To: "b", Relation: "test", Claim: "c"}
+		for range 2 {
+			if err := idx.insertEdges(ctx, []Edge{edge}); err != nil {
+				t.Fatalf("insertEdges: %v", err)
+			}
+		}
+		edges, err := idx.Edges(ctx, "a")
+		if err != nil {
+			t.Fatalf("Edges: %v", err)
+		}
+		if len(edges) != 1 {
+			t.Errorf("Edges = %v, want the edge once", edges)
+		}
+	})
+}
+
+func TestSearch(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, idx *Index) {
+		ctx := context.Background()
+		for _, name := range []string{"alice smith", "bob jones", "100% done", "1000 done", "a_b", "axb"} {
+			if _, err := idx.Store(ctx, &rpc.Contact{Name: name}); err != nil {
+				t.Fatalf("Store: %v", err)
+			}
+		}
+
+		columns, err := idx.Columns(ctx)
+		if err != nil {
+			t.Fatalf("Columns: %v", err)
+		}
+		quoted := make([]string, len(columns))
+		for n, c := range columns {
+			quoted[n] = QuoteIdent(c)
+		}
+
+		backend := idx.Backend()
+		tests := []struct {
+			name string
+			cond sq.Sqlizer
+			want []string
+		}{
+			{"search", backend.Search("alice", quoted), []string{"alice smith"}},
+			{"like", backend.Like(`"name"`, "%JONES%"), []string{"bob jones"}},
+			{"like percent", backend.Like(`"name"`, "%"+EscapeLike("100%")+"%"), []string{"100% done"}},
+			{"like underscore", backend.Like(`"name"`, EscapeLike("a_b")), []string{"a_b"}},
+		}
+
+		for _, tt := range tests {
+			claims, err := idx.Query(ctx, idx.Q.Where(sq.Eq{"schema_kind": "Contact"}).Where(tt.cond))
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			var got []string
+			for _, c := range claims {
+				got = append(got, c.Metadata["name"].(string))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/schema"
+)
+
+// changesBatchSize is the number of changes Subscribe reads at a time
+const changesBatchSize = 100
+
+// changesPollInterval is how often Subscribe checks for changes written by
+// other hosts sharing the database.
+const changesPollInterval = 5 * time.Second
+
+// Change is recorded when a claim is applied to the index. Changes are
+// numbered in the order they were applied, so consumers can resume after Seq.
+type Change struct {
+	Seq int64 `json:"seq"`
+
+	// Type is the claim type: "content", "permanode_version", "data_source" or "delete"
+	Type          string    `json:"type"`
+	ClaimHash     string    `json:"claim_hash"`
+	SchemaKind    string    `json:"schema_kind,omitempty"`
+	ContentHash   string    `json:"content_hash,omitempty"`
+	PermanodeHash string    `json:"permanode_hash,omitempty"`
+	DeleteHash    string    `json:"delete_hash,omitempty"`
+	Timestamp     time.Time `json:"timestamp,omitzero"`
+}
+
+var changeColumns = []string{
+	"seq", "type", "claim_hash", "schema_kind", "content_hash", "permanode_hash", "delete_hash", "timestamp",
+}
+
+// recordChange adds a change for an applied claim and wakes up subscribers.
+// Claims that were already recorded, e.g. during a rebuild, are ignored.
+func (i *Index) recordChange(ctx context.Context, claimHash, schemaKind string, claim schema.Claim) error {
+	var timestamp interface{}
+	if !claim.Timestamp.IsZero() {
+		timestamp = claim.Timestamp.UnixMilli()
+	}
+
+	insert := i.backend.InsertIgnore(i.sb.Insert("changes").
+		Columns(changeColumns[1:]...).
+		Values(claim.Type, claimHash, schemaKind, claim.ContentHash, claim.PermanodeHash, claim.DeleteHash, timestamp))
+	if err := i.backend.InsertChange(ctx, i.db, insert); err != nil {
+		return fmt.Errorf("failed to record change: %w", err)
+	}
+
+	i.mu.Lock()
+	close(i.changed)
+	i.changed = make(chan struct{})
+	i.mu.Unlock()
+
+	return nil
+}
+
+// Changes returns up to limit changes after the sequence number since.
+func (i *Index) Changes(ctx context.Context, since int64, limit int) ([]Change, error) {
+	if err := i.createTables(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := i.sb.Select(changeColumns...).
+		From("changes").
+		Where(sq.Gt{"seq": since}).
+		OrderBy("seq").
+		Limit(uint64(limit)).
+		RunWith(i.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []Change
+	for rows.Next() {
+		var c Change
+		var schemaKind, contentHash, permanodeHash, deleteHash sql.NullString
+		var timestamp sql.NullInt64
+		if err := rows.Scan(&c.Seq, &c.Type, &c.ClaimHash, &schemaKind, &contentHash, &permanodeHash, &deleteHash, &timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan change: %w", err)
+		}
+
+		c.SchemaKind = schemaKind.String
+		c.ContentHash = contentHash.String
+		c.PermanodeHash = permanodeHash.String
+		c.DeleteHash = deleteHash.String
+		if timestamp.Valid {
+			c.Timestamp = time.UnixMilli(timestamp.Int64)
+		}
+
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// LatestSeq returns the sequence number of the last change, or 0 if there are none.
+func (i *Index) LatestSeq(ctx context.Context) (int64, error) {
+	if err := i.createTables(ctx); err != nil {
+		return 0, err
+	}
+
+	var seq sql.NullInt64
+	if err := i.sb.Select("MAX(seq)").
+		From("changes").
+		RunWith(i.db).
+		QueryRowContext(ctx).
+		Scan(&seq); err != nil {
+		return 0, fmt.Errorf("failed to query latest change: %w", err)
+	}
+
+	return seq.Int64, nil
+}
+
+// Subscribe yields every change after the sequence number since, first catching
+// up on recorded changes and then waiting for new ones until ctx is done.
+func (i *Index) Subscribe(ctx context.Context, since int64) iter.Seq2[Change, error] {
+	return func(yield func(Change, error) bool) {
+		poll := time.NewTicker(changesPollInterval)
+		defer poll.Stop()
+
+		for {
+			// Get the channel before reading, so a change recorded in between
+			// isn't missed
+			i.mu.RLock()
+			changed := i.changed
+			i.mu.RUnlock()
+
+			changes, err := i.Changes(ctx, since, changesBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					yield(Change{}, err)
+				}
+				return
+			}
+
+			for _, c := range changes {
+				if !yield(c, nil) {
+					return
+				}
+				since = c.Seq
+			}
+
+			if len(changes) == changesBatchSize {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			case <-poll.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package index
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.quinn.io/dataq/rpc"
+)
+
+func TestChanges(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, idx *Index) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		store := func(name string) string {
+			t.Helper()
+			hash, err := idx.Store(ctx, &rpc.Contact{Name: name})
+			if err != nil {
+				t.Fatalf("Store: %v", err)
+			}
+			return hash
+		}
+
+		if seq, err := idx.LatestSeq(ctx); err != nil || seq != 0 {
+			t.Fatalf("LatestSeq = %d, %v, want 0", seq, err)
+		}
+
+		hashes := []string{store("a"), store("b"), store("c")}
+		// Stored again, not a change
+		store("a")
+
+		changes, err := idx.Changes(ctx, 0, 10)
+		if err != nil {
+			t.Fatalf("Changes: %v", err)
+		}
+		if len(changes) != len(hashes) {
+			t.Fatalf("Changes = %+v, want %d changes", changes, len(hashes))
+		}
+		for n, c := range changes {
+			if c.ContentHash != hashes[n] || c.Type != "content" || c.SchemaKind != "Contact" {
+				t.Errorf("change %d = %+v, want content %s", n, c, hashes[n])
+			}
+			if n > 0 && c.Seq <= changes[n-1].Seq {
+				t.Errorf("change %d seq %d isn't after %d", n, c.Seq, changes[n-1].Seq)
+			}
+		}
+
+		if seq, err := idx.LatestSeq(ctx); err != nil || seq != changes[2].Seq {
+			t.Errorf("LatestSeq = %d, %v, want %d", seq, err, changes[2].Seq)
+		}
+
+		after, err := idx.Changes(ctx, changes[0].Seq, 1)
+		if err != nil {
+			t.Fatalf("Changes: %v", err)
+		}
+		if len(after) != 1 || after[0].Seq != changes[1].Seq {
+			t.Errorf("Changes after %d = %+v, want seq %d", changes[0].Seq, after, changes[1].Seq)
+		}
+
+		// Subscribe catches up, then waits for new changes
+		var got []string
+		for c, err := range idx.Subscribe(ctx, changes[1].Seq) {
+			if err != nil {
+				t.Fatalf("Subscribe: %v", err)
+			}
+			got = append(got, c.ContentHash)
+			if len(got) == 1 {
+				hashes = append(hashes, store("d"))
+			}
+			if len(got) == 2 {
+				break
+			}
+		}
+		if len(got) != 2 || got[0] != hashes[2] || got[1] != hashes[3] {
+			t.Errorf("Subscribe = %v, want %v", got, hashes[2:])
+		}
+	})
+}
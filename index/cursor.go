@@ -0,0 +1,94 @@
+package index
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/schema"
+)
+
+// rowidColumn is the alias the backend row id is selected as when paging.
+const rowidColumn = "_rowid"
+
+// Cursor is a position in a result set ordered by timestamp and rowid, newest
+// first. Rows inserted after a cursor was created don't shift later pages.
+type Cursor struct {
+	Timestamp int64
+	RowID     int64
+}
+
+func (c Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(c.Timestamp, 10) + ":" + strconv.FormatInt(c.RowID, 10)))
+}
+
+// ParseCursor parses a cursor returned by Page.
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	ts, rowid, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return c, fmt.Errorf("invalid cursor: %q", s)
+	}
+
+	if c.Timestamp, err = strconv.ParseInt(ts, 10, 64); err != nil {
+		return c, fmt.Errorf("invalid cursor timestamp: %w", err)
+	}
+	if c.RowID, err = strconv.ParseInt(rowid, 10, 64); err != nil {
+		return c, fmt.Errorf("invalid cursor rowid: %w", err)
+	}
+
+	return c, nil
+}
+
+// Page returns up to limit claims matching query, newest first, starting after
+// cursor. An empty cursor starts at the first page. The returned cursor is
+// passed to the next call, it is empty when there are no more results.
+// query should select from index_data without an ORDER BY or LIMIT.
+func (i *Index) Page(ctx context.Context, query sq.SelectBuilder, cursor string, limit int) ([]schema.Claim, string, error) {
+	rowid := i.backend.RowID()
+	query = query.
+		Column(rowid+" AS "+rowidColumn).
+		OrderBy("COALESCE(timestamp, 0) DESC", rowid+" DESC").
+		Limit(uint64(limit) + 1)
+
+	if cursor != "" {
+		c, err := ParseCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		query = query.Where(
+			"(COALESCE(timestamp, 0) < ? OR (COALESCE(timestamp, 0) = ? AND "+rowid+" < ?))",
+			c.Timestamp, c.Timestamp, c.RowID)
+	}
+
+	var claims []schema.Claim
+	var last Cursor
+	for row, err := range i.queryRows(ctx, query) {
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(claims) == limit {
+			return claims, last.String(), nil
+		}
+
+		claims = append(claims, row.claim)
+		last = Cursor{RowID: row.rowid}
+		if !row.claim.Timestamp.IsZero() {
+			last.Timestamp = row.claim.Timestamp.UnixMilli()
+		}
+	}
+
+	return claims, "", nil
+}
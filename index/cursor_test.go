@@ -0,0 +1,113 @@
+package index
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/rpc"
+)
+
+func TestCursor(t *testing.T) {
+	tests := []Cursor{
+		{},
+		{Timestamp: 1735689600000, RowID: 42},
+		{Timestamp: 0, RowID: 7},
+		{Timestamp: -1, RowID: 1},
+		{Timestamp: math.MaxInt64, RowID: math.MaxInt64},
+	}
+
+	for _, want := range tests {
+		s := want.String()
+		t.Run(s, func(t *testing.T) {
+			got, err := ParseCursor(s)
+			if err != nil {
+				t.Fatalf("ParseCursor(%q): %v", s, err)
+			}
+			if got != want {
+				t.Errorf("ParseCursor(%q) = %+v, want %+v", s, got, want)
+			}
+		})
+	}
+}
+
+func TestParseCursorErrors(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!"},
+		{"padded", encode("1:2") + "="},
+		{"no separator", encode("12")},
+		{"bad timestamp", encode("x:2")},
+		{"bad rowid", encode("1:x")},
+		{"extra separator", encode("1:2:3")},
+		{"empty parts", encode(":")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := ParseCursor(tt.cursor); err == nil {
+				t.Errorf("ParseCursor(%q) = %+v, want an error", tt.cursor, c)
+			}
+		})
+	}
+}
+
+func TestPage(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, idx *Index) {
+		ctx := context.Background()
+		store := func(name string) string {
+			t.Helper()
+			hash, err := idx.Store(ctx, &rpc.Contact{Name: name})
+			if err != nil {
+				t.Fatalf("Store: %v", err)
+			}
+			return hash
+		}
+
+		var want []string
+		for n := range 5 {
+			want = append(want, store(fmt.Sprint("contact ", n)))
+		}
+		slices.Reverse(want)
+
+		var got []string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			if pages > len(want) {
+				t.Fatalf("Page didn't end, got %v", got)
+			}
+
+			claims, next, err := idx.Page(ctx, idx.Q.Where(sq.Eq{"schema_kind": "Contact"}), cursor, 2)
+			if err != nil {
+				t.Fatalf("Page: %v", err)
+			}
+			for _, c := range claims {
+				got = append(got, c.ContentHash)
+			}
+
+			// Newer rows don't shift the following pages
+			if pages == 0 {
+				store("newer")
+			}
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("pages = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,509 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+	"go.quinn.io/dataq/validate"
+	"golang.org/x/exp/slog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Dynamic is content of a kind that is not compiled into the host. Its type is
+// described by a descriptor set stored in the CAS, usually shipped by a plugin
+// in its InstallResponse.
+type Dynamic struct {
+	*dynamicpb.Message
+
+	descriptorHash string
+}
+
+func newDynamic(md protoreflect.MessageDescriptor, descriptorHash string) *Dynamic {
+	return &Dynamic{
+		Message:        dynamicpb.NewMessage(md),
+		descriptorHash: descriptorHash,
+	}
+}
+
+func (d *Dynamic) SchemaKind() string {
+	return string(d.Descriptor().Name())
+}
+
+// DescriptorHash is the address of the descriptor set describing the content.
+func (d *Dynamic) DescriptorHash() string {
+	return d.descriptorHash
+}
+
+// SchemaMetadata returns the populated fields, named and converted like the
+// generated SchemaMetadata methods according to their dataq.index options.
+func (d *Dynamic) SchemaMetadata() map[string]interface{} {
+	metadata := map[string]interface{}{
+		"descriptor_hash": d.descriptorHash,
+	}
+
+	var fulltext []string
+	seen := map[protoreflect.FullName]bool{d.Descriptor().FullName(): true}
+	dynamicMetadata(metadata, &fulltext, d, "", seen)
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
+	}
+
+	return metadata
+}
+
+// SchemaKey joins the unique_key fields, like the generated SchemaKey methods.
+func (d *Dynamic) SchemaKey() string {
+	var keys []string
+	fields := d.Descriptor().Fields()
+	for n := 0; n < fields.Len(); n++ {
+		fd := fields.Get(n)
+		if !indexOptions(fd).GetUniqueKey() || fd.IsList() || fd.Message() != nil {
+			continue
+		}
+
+		v := d.Get(fd)
+		if fd.Kind() == protoreflect.EnumKind {
+			if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+				keys = append(keys, string(ev.Name()))
+				continue
+			}
+		}
+		keys = append(keys, fmt.Sprint(v.Interface()))
+	}
+
+	return strings.Join(keys, "/")
+}
+
+// SchemaVersion reads the dataq.kind option of the message, like the generated
+// SchemaVersion methods.
+func (d *Dynamic) SchemaVersion() int {
+	opts, _ := proto.GetExtension(d.Descriptor().Options(), rpc.E_Kind).(*rpc.KindOptions)
+	if v := opts.GetVersion(); v > 0 {
+		return int(v)
+	}
+	return 1
+}
+
+// Validate checks the dataq.validate options of the fields, like the generated
+// Validate methods.
+func (d *Dynamic) Validate() error {
+	return validateMessage(d)
+}
+
+func validateMessage(m protoreflect.Message) error {
+	var errs validate.Errors
+
+	fields := m.Descriptor().Fields()
+	for n := 0; n < fields.Len(); n++ {
+		fd := fields.Get(n)
+		opts, _ := proto.GetExtension(fd.Options(), rpc.E_Validate).(*rpc.ValidateOptions)
+		name := fd.TextName()
+
+		if opts.GetRequired() && !m.Has(fd) {
+			errs.Add(name, validate.ErrRequired)
+		}
+
+		var check func(string) error
+		switch opts.GetFormat() {
+		case rpc.ValidateOptions_EMAIL:
+			check = validate.Email
+		case rpc.ValidateOptions_DATE:
+			check = validate.Date
+		case rpc.ValidateOptions_URL:
+			check = validate.URL
+		}
+
+		switch {
+		case !m.Has(fd), fd.IsMap():
+		case fd.IsList() && fd.Kind() == protoreflect.StringKind && check != nil:
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				errs.Add(validate.Index(name, i), check(list.Get(i).String()))
+			}
+		case fd.IsList() && fd.Message() != nil:
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				errs.Add(validate.Index(name, i), validateMessage(list.Get(i).Message()))
+			}
+		case fd.Kind() == protoreflect.StringKind && check != nil:
+			errs.Add(name, check(m.Get(fd).String()))
+		case fd.Message() != nil:
+			errs.Add(name, validateMessage(m.Get(fd).Message()))
+		}
+	}
+
+	return errs.Err()
+}
+
+// dynamicMetadata adds the populated fields of m to metadata, flattening
+// nested messages with prefix.
+func dynamicMetadata(metadata map[string]interface{}, fulltext *[]string, m protoreflect.Message, prefix string, seen map[protoreflect.FullName]bool) {
+	// composite are the JSON values of m, marshaled once for lists, maps
+	// and messages
+	var composite map[string]json.RawMessage
+
+	fields := m.Descriptor().Fields()
+	for n := 0; n < fields.Len(); n++ {
+		fd := fields.Get(n)
+		opts := indexOptions(fd)
+		if !m.Has(fd) || opts.GetSkip() {
+			continue
+		}
+
+		name := prefix + fd.TextName()
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			name = prefix + string(oneof.Name()) + "_" + fd.TextName()
+		}
+
+		v := m.Get(fd)
+		typ := opts.GetType()
+		message := fd.Message() != nil && !fd.IsMap()
+
+		switch {
+		case opts.GetFulltext():
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() {
+				*fulltext = append(*fulltext, v.String())
+			}
+		case typ == rpc.IndexOptions_DEFAULT && message && !fd.IsList() && fd.Message().FullName() == "google.protobuf.Timestamp":
+			metadata[name] = timestampMillis(v.Message())
+		case typ == rpc.IndexOptions_DEFAULT && message && !fd.IsList() && !seen[fd.Message().FullName()]:
+			seen[fd.Message().FullName()] = true
+			dynamicMetadata(metadata, fulltext, v.Message(), name+".", seen)
+			delete(seen, fd.Message().FullName())
+		case fd.IsList(), fd.IsMap(), message:
+			if typ != rpc.IndexOptions_DEFAULT && typ != rpc.IndexOptions_JSON {
+				continue
+			}
+			if composite == nil {
+				b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m.Interface())
+				if err != nil || json.Unmarshal(b, &composite) != nil {
+					continue
+				}
+			}
+			metadata[name] = composite[fd.TextName()]
+		default:
+			if value, ok := scalarValue(fd, typ, v); ok {
+				metadata[name] = value
+			}
+		}
+	}
+}
+
+// scalarValue converts a scalar field to the type it is indexed as
+func scalarValue(fd protoreflect.FieldDescriptor, typ rpc.IndexOptions_Type, v protoreflect.Value) (interface{}, bool) {
+	kind := fd.Kind()
+	integer := false
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind,
+		protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		integer = true
+	}
+
+	switch {
+	case kind == protoreflect.EnumKind && (typ == rpc.IndexOptions_DEFAULT || typ == rpc.IndexOptions_JSON):
+		return int64(v.Enum()), true
+	case typ == rpc.IndexOptions_DEFAULT, typ == rpc.IndexOptions_JSON:
+		return v.Interface(), true
+	case typ == rpc.IndexOptions_TEXT && kind == protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), true
+		}
+		return fmt.Sprint(v.Enum()), true
+	case typ == rpc.IndexOptions_TEXT && kind != protoreflect.BytesKind:
+		return fmt.Sprint(v.Interface()), true
+	case typ == rpc.IndexOptions_INTEGER && kind == protoreflect.StringKind:
+		i, err := strconv.ParseInt(v.String(), 10, 64)
+		return i, err == nil
+	case typ == rpc.IndexOptions_REAL && kind == protoreflect.StringKind:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, err == nil
+	case typ == rpc.IndexOptions_TIMESTAMP && kind == protoreflect.StringKind:
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, v.String()); err == nil {
+				return t.UnixMilli(), true
+			}
+		}
+		return nil, false
+	case typ == rpc.IndexOptions_TIMESTAMP && integer:
+		return v.Int() * 1000, true
+	case (typ == rpc.IndexOptions_INTEGER || typ == rpc.IndexOptions_REAL) && integer,
+		typ == rpc.IndexOptions_REAL && (kind == protoreflect.FloatKind || kind == protoreflect.DoubleKind):
+		return v.Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+func timestampMillis(ts protoreflect.Message) int64 {
+	fields := ts.Descriptor().Fields()
+	seconds := ts.Get(fields.ByName("seconds")).Int()
+	nanos := ts.Get(fields.ByName("nanos")).Int()
+	return seconds*1000 + nanos/1e6
+}
+
+func indexOptions(fd protoreflect.FieldDescriptor) *rpc.IndexOptions {
+	opts, _ := proto.GetExtension(fd.Options(), rpc.E_Index).(*rpc.IndexOptions)
+	return opts
+}
+
+// dynamicTypes are the message types loaded from descriptor sets
+var dynamicTypes = struct {
+	sync.RWMutex
+
+	// sets maps the address of a descriptor set to its messages by kind
+	sets map[string]map[string]protoreflect.MessageDescriptor
+
+	// messages are the most recently loaded messages by full name
+	messages map[protoreflect.FullName]*Dynamic
+}{
+	sets:     make(map[string]map[string]protoreflect.MessageDescriptor),
+	messages: make(map[protoreflect.FullName]*Dynamic),
+}
+
+// StoreDescriptors stores a descriptor set in the CAS and registers the
+// messages it describes that are not compiled into the host. It returns the
+// address of the descriptor set.
+func (i *Index) StoreDescriptors(ctx context.Context, fds *descriptorpb.FileDescriptorSet) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(fds)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal descriptors: %w", err)
+	}
+
+	hash, err := i.cas.Store(ctx, bytes.NewReader(b))
+	if err != nil {
+		return "", fmt.Errorf("failed to store descriptors: %w", err)
+	}
+
+	if err := registerDescriptors(hash, fds); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// loadDescriptors registers the messages of a stored descriptor set, unless it
+// was already loaded.
+func (i *Index) loadDescriptors(ctx context.Context, hash string) error {
+	dynamicTypes.RLock()
+	_, loaded := dynamicTypes.sets[hash]
+	dynamicTypes.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	r, err := i.cas.Retrieve(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve descriptors: %w", err)
+	}
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read descriptors: %w", err)
+	}
+
+	fds := new(descriptorpb.FileDescriptorSet)
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return fmt.Errorf("failed to unmarshal descriptors: %w", err)
+	}
+
+	return registerDescriptors(hash, fds)
+}
+
+func registerDescriptors(hash string, fds *descriptorpb.FileDescriptorSet) error {
+	dynamicTypes.RLock()
+	_, loaded := dynamicTypes.sets[hash]
+	dynamicTypes.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	files, err := protodesc.NewFiles(withDependencies(fds))
+	if err != nil {
+		return fmt.Errorf("failed to load descriptors: %w", err)
+	}
+
+	dynamicTypes.Lock()
+	defer dynamicTypes.Unlock()
+
+	dynamicTypes.sets[hash] = make(map[string]protoreflect.MessageDescriptor)
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		registerMessages(hash, fd.Messages())
+		return true
+	})
+
+	return nil
+}
+
+// registerMessages registers messages and their nested messages. The caller
+// must hold the dynamicTypes lock.
+func registerMessages(hash string, messages protoreflect.MessageDescriptors) {
+	for n := 0; n < messages.Len(); n++ {
+		md := messages.Get(n)
+		registerMessages(hash, md.Messages())
+
+		if md.IsMapEntry() {
+			continue
+		}
+
+		// Messages compiled into the host keep their generated type
+		if _, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil {
+			continue
+		}
+
+		kind := string(md.Name())
+		dynamicTypes.sets[hash][kind] = md
+		dynamicTypes.messages[md.FullName()] = newDynamic(md, hash)
+
+		if existing, err := New(kind); err == nil {
+			if d, ok := existing.(*Dynamic); !ok || d.Descriptor().FullName() != md.FullName() {
+				slog.Warn("schema kind already registered", "kind", kind, "message", md.FullName())
+				continue
+			}
+		}
+
+		Register(kind, func() Indexable { return newDynamic(md, hash) })
+	}
+}
+
+// withDependencies adds the files a descriptor set imports that are compiled
+// into the host, such as the well known types, if the set doesn't include them.
+func withDependencies(fds *descriptorpb.FileDescriptorSet) *descriptorpb.FileDescriptorSet {
+	files := make(map[string]bool)
+	for _, f := range fds.GetFile() {
+		files[f.GetName()] = true
+	}
+
+	result := &descriptorpb.FileDescriptorSet{File: fds.GetFile()}
+	for n := 0; n < len(result.File); n++ {
+		for _, dep := range result.File[n].GetDependency() {
+			if files[dep] {
+				continue
+			}
+
+			fd, err := protoregistry.GlobalFiles.FindFileByPath(dep)
+			if err != nil {
+				// Left for NewFiles to report
+				continue
+			}
+
+			files[dep] = true
+			result.File = append(result.File, protodesc.ToFileDescriptorProto(fd))
+		}
+	}
+
+	return result
+}
+
+// UnmarshalAny returns the content of an Any payload. Messages compiled into
+// the host are returned as their generated type, others must have been
+// registered with StoreDescriptors and are returned as Dynamic.
+func UnmarshalAny(a *anypb.Any) (Indexable, error) {
+	name := a.MessageName()
+
+	var msg proto.Message
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(name); err == nil {
+		msg = mt.New().Interface()
+	} else {
+		dynamicTypes.RLock()
+		d, ok := dynamicTypes.messages[name]
+		dynamicTypes.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown payload type: %s", name)
+		}
+
+		msg = newDynamic(d.Descriptor(), d.descriptorHash)
+	}
+
+	if err := proto.Unmarshal(a.GetValue(), msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload %s: %w", name, err)
+	}
+
+	content, ok := msg.(Indexable)
+	if !ok {
+		return nil, fmt.Errorf("payload type is not indexable: %s", name)
+	}
+
+	return content, nil
+}
+
+// PermanodeContent returns the content of a permanode in a transform
+// response. Descriptors of Any payloads must have been stored first.
+func PermanodeContent(permanode *rpc.TransformResponse_Permanode) (Indexable, error) {
+	switch p := permanode.Payload.(type) {
+	case *rpc.TransformResponse_Permanode_Email:
+		return p.Email, nil
+	case *rpc.TransformResponse_Permanode_FinancialTransaction:
+		return p.FinancialTransaction, nil
+	case *rpc.TransformResponse_Permanode_Contact:
+		return p.Contact, nil
+	case *rpc.TransformResponse_Permanode_CalendarEvent:
+		return p.CalendarEvent, nil
+	case *rpc.TransformResponse_Permanode_MediaItem:
+		return p.MediaItem, nil
+	case *rpc.TransformResponse_Permanode_LocationPoint:
+		return p.LocationPoint, nil
+	case *rpc.TransformResponse_Permanode_Document:
+		return p.Document, nil
+	case *rpc.TransformResponse_Permanode_HealthMetric:
+		return p.HealthMetric, nil
+	case *rpc.TransformResponse_Permanode_ChatMessage:
+		return p.ChatMessage, nil
+	case *rpc.TransformResponse_Permanode_Bookmark:
+		return p.Bookmark, nil
+	case *rpc.TransformResponse_Permanode_Attachment:
+		return p.Attachment, nil
+	case *rpc.TransformResponse_Permanode_Any:
+		return UnmarshalAny(p.Any)
+	default:
+		return nil, fmt.Errorf("unknown payload type: %T", p)
+	}
+}
+
+// descriptorHash returns the descriptor set needed to unmarshal a claim's
+// content, if its kind is not compiled into the host.
+func descriptorHash(claim schema.Claim) string {
+	if claim.DescriptorHash != "" {
+		return claim.DescriptorHash
+	}
+
+	hash, _ := claim.Metadata["descriptor_hash"].(string)
+	return hash
+}
+
+// newDynamicKind returns an empty value of kind as described by a loaded
+// descriptor set, or false if the set doesn't describe kind.
+func newDynamicKind(hash, kind string) (*Dynamic, bool) {
+	dynamicTypes.RLock()
+	md, ok := dynamicTypes.sets[hash][kind]
+	dynamicTypes.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	return newDynamic(md, hash), true
+}
+
+// contentDescriptorHash returns the descriptor set of dynamic content.
+func contentDescriptorHash(content Indexable) string {
+	if d, ok := content.(*Dynamic); ok {
+		return d.descriptorHash
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package index
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/schema"
+)
+
+// fetchConcurrency is the number of CAS reads in flight during a batch fetch.
+const fetchConcurrency = 16
+
+// fetchBatchSize is the number of rows QueryContent reads before fetching
+// their content.
+const fetchBatchSize = 128
+
+// Content is a claim along with the content it references.
+type Content struct {
+	Claim schema.Claim
+	Data  Indexable
+}
+
+// FetchMany retrieves the content of each claim from CAS concurrently.
+// The results are in the same order as claims.
+func (i *Index) FetchMany(ctx context.Context, claims []schema.Claim) ([]Indexable, error) {
+	results := make([]Indexable, len(claims))
+	errs := make([]error, len(claims))
+
+	sem := make(chan struct{}, fetchConcurrency)
+	var wg sync.WaitGroup
+	for n, claim := range claims {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[n], errs[n] = i.UnmarshalContent(ctx, claim, claim.ContentHash)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// QueryContent executes a query and yields each matching claim with its
+// content. Rows are read in batches and each batch is fetched from CAS
+// concurrently.
+func (i *Index) QueryContent(ctx context.Context, query sq.SelectBuilder) iter.Seq2[Content, error] {
+	return func(yield func(Content, error) bool) {
+		batch := make([]schema.Claim, 0, fetchBatchSize)
+
+		flush := func() bool {
+			data, err := i.FetchMany(ctx, batch)
+			if err != nil {
+				yield(Content{}, err)
+				return false
+			}
+
+			for n, claim := range batch {
+				if !yield(Content{Claim: claim, Data: data[n]}, nil) {
+					return false
+				}
+			}
+
+			batch = batch[:0]
+			return true
+		}
+
+		for claim, err := range i.QueryIter(ctx, query) {
+			if err != nil {
+				yield(Content{}, err)
+				return
+			}
+
+			batch = append(batch, claim)
+			if len(batch) == fetchBatchSize && !flush() {
+				return
+			}
+		}
+
+		if len(batch) > 0 {
+			flush()
+		}
+	}
+}
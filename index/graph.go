@@ -2,97 +2,261 @@ package index
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/schema"
 )
 
+// Edge is a directed link between two objects in the CAS.
+// From references To, for example a TransformRequest (From) references the
+// ExtractResponse data it was created from (To).
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+
+	// Claim is the address of the claim that asserted this edge
+	Claim string `json:"claim"`
+}
+
 type Rel struct {
 	Type string `json:"type"`
 	Hash string `json:"hash"`
+
+	// Direction is "out" when the requested hash references Hash, "in" when
+	// Hash references the requested hash.
+	Direction string `json:"direction"`
 }
 
-func (i *Index) GetRels(ctx context.Context, hash string) ([]Rel, error) {
-	var or sq.Or
-	var fields []string
-	for name, err := range i.IterateFields(ctx) {
-		if err != nil {
-			return nil, err
+const createEdgesSQL = `CREATE TABLE IF NOT EXISTS edges (
+	from_hash TEXT NOT NULL,
+	to_hash TEXT NOT NULL,
+	relation TEXT NOT NULL,
+	claim_hash TEXT NOT NULL,
+	UNIQUE (from_hash, to_hash, relation, claim_hash)
+)`
+
+// claimEdges returns the edges asserted by a claim and the metadata of the
+// content it references.
+func claimEdges(claimHash string, claim schema.Claim, metadata map[string]interface{}) []Edge {
+	var edges []Edge
+	add := func(from, to, relation string) {
+		if from == "" || to == "" || from == to {
+			return
 		}
+		edges = append(edges, Edge{From: from, To: to, Relation: relation, Claim: claimHash})
+	}
 
-		if strings.HasSuffix(name, "_hash") {
-			fields = append(fields, name)
+	switch claim.Type {
+	case "delete":
+		add(claimHash, claim.DeleteHash, "delete")
+		return edges
+	case "data_source":
+		add(claim.PermanodeHash, claimHash, "data_source")
+		return edges
+	}
 
-			if name == "content_hash" {
-				continue
-			}
+	add(claim.ContentHash, claim.PermanodeHash, "permanode")
+	add(claim.ContentHash, claim.TransformResponseHash, "transform_response")
 
-			or = append(or, sq.Eq{name: hash})
+	for key, value := range metadata {
+		if !strings.HasSuffix(key, "_hash") {
+			continue
 		}
+
+		to, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		add(claim.ContentHash, to, strings.TrimSuffix(key, "_hash"))
 	}
 
-	rows, err := sq.
-		Select(fields...).
-		From("index_data").
-		Where(or).
-		OrderBy("timestamp DESC").
-		RunWith(i.db).
-		Query()
+	return edges
+}
+
+func (i *Index) insertEdges(ctx context.Context, edges []Edge) error {
+	if len(edges) == 0 {
+		return nil
+	}
+
+	insert := i.backend.InsertIgnore(i.sb.Insert("edges").
+		Columns("from_hash", "to_hash", "relation", "claim_hash"))
+	for _, e := range edges {
+		insert = insert.Values(e.From, e.To, e.Relation, e.Claim)
+	}
+
+	if _, err := insert.RunWith(i.db).ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to insert edges: %w", err)
+	}
+
+	return nil
+}
+
+// Edges returns all edges that start or end at hash.
+func (i *Index) Edges(ctx context.Context, hash string) ([]Edge, error) {
+	sel := i.sb.Select("from_hash", "to_hash", "relation", "claim_hash").
+		From("edges").
+		Where(sq.Or{
+			sq.Eq{"from_hash": hash},
+			sq.Eq{"to_hash": hash},
+		})
+
+	return i.queryEdges(ctx, sel)
+}
+
+// Ancestors returns the edges reachable by following references out of hash,
+// up to maxDepth hops. These are the objects hash was derived from.
+func (i *Index) Ancestors(ctx context.Context, hash string, maxDepth int) ([]Edge, error) {
+	return i.traverse(ctx, hash, maxDepth, "from_hash", "to_hash")
+}
+
+// Descendants returns the edges reachable by following references into hash,
+// up to maxDepth hops. These are the objects derived from hash.
+func (i *Index) Descendants(ctx context.Context, hash string, maxDepth int) ([]Edge, error) {
+	return i.traverse(ctx, hash, maxDepth, "to_hash", "from_hash")
+}
+
+// traverse walks the edges table recursively, joining on the start column and
+// continuing from the next column.
+func (i *Index) traverse(ctx context.Context, hash string, maxDepth int, start, next string) ([]Edge, error) {
+	if err := i.createTables(ctx); err != nil {
+		return nil, err
+	}
+
+	query := `WITH RECURSIVE walk(hash, depth) AS (
+		SELECT CAST(? AS TEXT), 0
+		UNION
+		SELECT e.` + next + `, walk.depth + 1
+		FROM edges e
+		JOIN walk ON e.` + start + ` = walk.hash
+		WHERE walk.depth < ?
+	)
+	SELECT DISTINCT e.from_hash, e.to_hash, e.relation, e.claim_hash
+	FROM edges e
+	JOIN walk ON e.` + start + ` = walk.hash
+	WHERE walk.depth < ?`
+
+	query, err := i.backend.Placeholder().ReplacePlaceholders(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, err
+	}
+
+	rows, err := i.db.QueryContext(ctx, query, hash, maxDepth, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to traverse edges: %w", err)
 	}
 	defer rows.Close()
 
-	var results []Rel
-	for rows.Next() {
-		// Prepare a slice for the column values and a slice of pointers to each item.
-		// The pointers slice (`valuePtrs`) is passed to rows.Scan.
-		values := make([]interface{}, len(fields))
-		valuePtrs := make([]interface{}, len(fields))
-		for i := range fields {
-			valuePtrs[i] = &values[i]
-		}
+	return scanEdges(rows)
+}
 
-		// Scan the row into the pointers slice.
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
+// ShortestPath returns the edges along the shortest path between from and to,
+// ignoring edge direction. It returns nil if the hashes are not connected
+// within maxDepth hops.
+func (i *Index) ShortestPath(ctx context.Context, from, to string, maxDepth int) ([]Edge, error) {
+	if from == to {
+		return nil, nil
+	}
 
-		var entry Rel
-		for i, field := range fields {
-			if field == "content_hash" {
-				sval, ok := values[i].(string)
-				if !ok {
-					return nil, fmt.Errorf("unexpected value for content_hash %q", values[i])
-				}
-				entry.Hash = sval
-				continue
-			}
-			ival := values[i]
-			if ival == nil {
-				continue
-			}
+	// prev maps a visited hash to the edge used to reach it
+	prev := map[string]Edge{from: {}}
+	frontier := []string{from}
 
-			sval, ok := ival.(string)
-			if !ok {
-				return nil, fmt.Errorf("unexpected type for field %q", field)
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		var next []string
+		for _, hash := range frontier {
+			edges, err := i.Edges(ctx, hash)
+			if err != nil {
+				return nil, err
 			}
 
-			if sval == "" {
-				continue
+			for _, e := range edges {
+				neighbor := e.To
+				if neighbor == hash {
+					neighbor = e.From
+				}
+				if _, seen := prev[neighbor]; seen {
+					continue
+				}
+
+				prev[neighbor] = e
+				if neighbor == to {
+					return buildPath(prev, from, to), nil
+				}
+				next = append(next, neighbor)
 			}
+		}
+		frontier = next
+	}
 
-			// if sval != hash {
-			// 	return nil, fmt.Errorf("unexpected value for field %q: %q", field, sval)
-			// }
+	return nil, nil
+}
 
-			typeName := strings.TrimSuffix(field, "_hash")
-			entry.Type = typeName
+func buildPath(prev map[string]Edge, from, to string) []Edge {
+	var path []Edge
+	for hash := to; hash != from; {
+		e := prev[hash]
+		path = append([]Edge{e}, path...)
+		if e.To == hash {
+			hash = e.From
+		} else {
+			hash = e.To
 		}
+	}
+	return path
+}
+
+// GetRels returns the objects directly linked to hash, with the relation and
+// direction of each link.
+func (i *Index) GetRels(ctx context.Context, hash string) ([]Rel, error) {
+	edges, err := i.Edges(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
 
-		results = append(results, entry)
+	results := make([]Rel, 0, len(edges))
+	for _, e := range edges {
+		if e.From == hash {
+			results = append(results, Rel{Type: e.Relation, Hash: e.To, Direction: "out"})
+		} else {
+			results = append(results, Rel{Type: e.Relation, Hash: e.From, Direction: "in"})
+		}
 	}
 
 	return results, nil
 }
+
+func (i *Index) queryEdges(ctx context.Context, sel sq.SelectBuilder) ([]Edge, error) {
+	if err := i.createTables(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := sel.RunWith(i.db).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEdges(rows)
+}
+
+func scanEdges(rows *sql.Rows) ([]Edge, error) {
+	var edges []Edge
+	for rows.Next() {
+		var e Edge
+		if err := rows.Scan(&e.From, &e.To, &e.Relation, &e.Claim); err != nil {
+			return nil, fmt.Errorf("failed to scan edge: %w", err)
+		}
+		edges = append(edges, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate edges: %w", err)
+	}
+
+	return edges, nil
+}
@@ -9,37 +9,87 @@ import (
 	"io"
 	"iter"
 	"log"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/canonical"
 	"go.quinn.io/dataq/cas"
-	"go.quinn.io/dataq/rpc"
 	"go.quinn.io/dataq/schema"
 	"golang.org/x/exp/slog"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Index struct {
-	cas cas.Storage
-	db  *sql.DB
-	Q   sq.SelectBuilder
+	cas     cas.Storage
+	db      *sql.DB
+	backend Backend
+	sb      sq.StatementBuilderType
+	Q       sq.SelectBuilder
+
+	mu        sync.RWMutex
+	columns   []string
+	columnsAt time.Time
+	created   bool
+
+	// changed is closed and replaced whenever a change is recorded
+	changed chan struct{}
 }
 
+// columnsTTL is how long the column list is cached. Other hosts sharing the
+// database may add columns at any time.
+const columnsTTL = time.Minute
+
+// NewIndex returns an index stored in a SQLite database.
 func NewIndex(cas cas.Storage, db *sql.DB) *Index {
+	return NewIndexWithBackend(cas, db, SQLite{})
+}
+
+// NewIndexWithBackend returns an index stored in db, which must be opened with
+// a driver for backend.
+func NewIndexWithBackend(cas cas.Storage, db *sql.DB, backend Backend) *Index {
+	sb := sq.StatementBuilder.PlaceholderFormat(backend.Placeholder())
 	return &Index{
-		cas: cas,
-		db:  db,
-		Q:   sq.Select("*").From("index_data"),
+		cas:     cas,
+		db:      db,
+		backend: backend,
+		sb:      sb,
+		Q:       sb.Select("*").From("index_data"),
+		changed: make(chan struct{}),
 	}
 }
 
+// Backend returns the database backend of the index.
+func (i *Index) Backend() Backend {
+	return i.backend
+}
+
 type Indexable interface {
 	SchemaMetadata() map[string]interface{}
 	SchemaKind() string
 }
 
+// Keyed is implemented by kinds with unique_key fields. The key identifies the
+// entity within its kind.
+type Keyed interface {
+	SchemaKey() string
+}
+
+// Versioned is implemented by kinds with a schema version. Content of kinds
+// without one is version 1.
+type Versioned interface {
+	SchemaVersion() int
+}
+
+// Validator is implemented by kinds that can check their content before it is
+// stored.
+type Validator interface {
+	Validate() error
+}
+
 type IndexableProto interface {
 	protoreflect.ProtoMessage
 	Indexable
@@ -52,22 +102,41 @@ func (i *Index) Store(ctx context.Context, data Indexable) (string, error) {
 	}
 
 	claim := schema.NewContent(data.SchemaKind(), contentHash)
-	if _, err := i.marshalToCAS(ctx, claim); err != nil {
+	claim.DescriptorHash = contentDescriptorHash(data)
+	claim.SchemaVersion = schemaVersion(data)
+	claimHash, err := i.marshalToCAS(ctx, claim)
+	if err != nil {
 		return "", err
 	}
 
-	err = i.index(ctx, *claim, data)
+	err = i.index(ctx, claimHash, *claim, data)
 	return contentHash, err
 }
 
 func (i *Index) CreatePermanode(ctx context.Context, content Indexable) (string, error) {
+	return i.createPermanode(ctx, source{}, content)
+}
+
+// source is where the content of a permanode version came from. It is empty
+// for permanodes managed by dataq.
+type source struct {
+	// transformResponseHash is the TransformResponse that produced the content
+	transformResponseHash string
+
+	// instance is the PluginInstance the transform was for, and key the
+	// plugin key of the permanode
+	instance string
+	key      string
+}
+
+func (i *Index) createPermanode(ctx context.Context, src source, content Indexable) (string, error) {
 	permanode := schema.NewPermanode(content.SchemaKind())
 	permanodeHash, err := i.marshalToCAS(ctx, permanode)
 	if err != nil {
 		return "", fmt.Errorf("failed to create permanode: %w", err)
 	}
 
-	if _, err := i.UpdatePermanode(ctx, permanodeHash, content); err != nil {
+	if _, err := i.updatePermanode(ctx, permanodeHash, src, content); err != nil {
 		return "", fmt.Errorf("failed to update permanode: %w", err)
 	}
 
@@ -75,61 +144,122 @@ func (i *Index) CreatePermanode(ctx context.Context, content Indexable) (string,
 }
 
 func (i *Index) UpdatePermanode(ctx context.Context, permanodeHash string, content Indexable) (string, error) {
+	return i.updatePermanode(ctx, permanodeHash, source{}, content)
+}
+
+// updatePermanode creates a new permanode version. src is set when the content
+// was produced by a plugin transform.
+func (i *Index) updatePermanode(ctx context.Context, permanodeHash string, src source, content Indexable) (string, error) {
 	contentHash, err := i.marshalToCAS(ctx, content)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal content to CAS: %w", err)
 	}
 
 	permanodeVersion := schema.NewPermanodeVersion(permanodeHash, contentHash)
+	permanodeVersion.TransformResponseHash = src.transformResponseHash
+	permanodeVersion.PluginID = src.instance
+	permanodeVersion.PluginKey = src.key
+	permanodeVersion.DescriptorHash = contentDescriptorHash(content)
+	permanodeVersion.SchemaVersion = schemaVersion(content)
 	permanodeVersionHash, err := i.marshalToCAS(ctx, permanodeVersion)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal permanode version to CAS: %w", err)
 	}
 
-	if err := i.index(ctx, *permanodeVersion, content); err != nil {
+	if err := i.index(ctx, permanodeVersionHash, *permanodeVersion, content); err != nil {
 		return "", fmt.Errorf("failed to index permanode version: %w", err)
 	}
 
 	return permanodeVersionHash, nil
 }
 
-func (i *Index) CreateDataSource(ctx context.Context, pluginID, pluginKey string, content Indexable) (string, error) {
+// CreateDataSource creates a permanode managed by a plugin instance, or a new
+// version of the permanode that already exists for the plugin key if its
+// content changed. transformResponseHash is the address of the
+// TransformResponse that produced the content.
+func (i *Index) CreateDataSource(ctx context.Context, instance, pluginKey, transformResponseHash string, content Indexable) (string, error) {
+	src := source{transformResponseHash: transformResponseHash, instance: instance, key: pluginKey}
+
 	sel := i.Q.
-		Where(sq.Eq{"plugin_id": pluginID}).
-		Where(sq.Eq{"plugin_key": pluginKey})
+		Where(sq.Eq{"schema_kind": "DataSource"}).
+		Where(sq.Eq{"plugin_id": instance}).
+		Where(sq.Eq{"plugin_key": pluginKey}).
+		Limit(1)
 	claims, err := i.Query(ctx, sel)
 	if err != nil {
 		return "", fmt.Errorf("failed to query index: %w", err)
 	}
 
 	if len(claims) > 0 {
-		return claims[0].PermanodeHash, nil
+		permanodeHash := claims[0].PermanodeHash
+		if err := i.updateDataSource(ctx, permanodeHash, src, content); err != nil {
+			return "", err
+		}
+		return permanodeHash, nil
 	}
 
-	permanodeHash, err := i.CreatePermanode(ctx, content)
+	permanodeHash, err := i.createPermanode(ctx, src, content)
 	if err != nil {
 		return "", fmt.Errorf("failed to create permanode: %w", err)
 	}
 
-	dataSource := schema.NewDataSource(permanodeHash, pluginID, pluginKey)
-	if _, err := i.marshalToCAS(ctx, dataSource); err != nil {
+	dataSource := schema.NewDataSource(permanodeHash, instance, pluginKey)
+	dataSourceHash, err := i.marshalToCAS(ctx, dataSource)
+	if err != nil {
 		return "", fmt.Errorf("failed to create data source: %w", err)
 	}
 
-	if err := i.index(ctx, *dataSource, content); err != nil {
+	if err := i.index(ctx, dataSourceHash, *dataSource, dataSourceContent(*dataSource)); err != nil {
 		return "", fmt.Errorf("failed to index data source: %w", err)
 	}
 
 	return permanodeHash, nil
 }
 
+// updateDataSource creates a new version of a plugin managed permanode unless
+// the latest version has the same content.
+func (i *Index) updateDataSource(ctx context.Context, permanodeHash string, src source, content Indexable) error {
+	contentHash, err := i.marshalToCAS(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content to CAS: %w", err)
+	}
+
+	versions, err := i.Query(ctx, i.Q.
+		Where(sq.Eq{"permanode_hash": permanodeHash}).
+		Where(sq.NotEq{"content_hash": ""}).
+		OrderBy("timestamp DESC").
+		Limit(1))
+	if err != nil {
+		return fmt.Errorf("failed to query permanode versions: %w", err)
+	}
+	if len(versions) > 0 && versions[0].ContentHash == contentHash {
+		return nil
+	}
+
+	if _, err := i.updatePermanode(ctx, permanodeHash, src, content); err != nil {
+		return fmt.Errorf("failed to update permanode: %w", err)
+	}
+	return nil
+}
+
+// dataSourceContent is what a data_source claim is indexed with
+func dataSourceContent(claim schema.Claim) *schema.DataSource {
+	return &schema.DataSource{
+		DataQType:     claim.Type,
+		PermanodeHash: claim.PermanodeHash,
+		PluginID:      claim.PluginID,
+		PluginKey:     claim.PluginKey,
+	}
+}
+
 func (i *Index) Delete(ctx context.Context, hash string) error {
 	del := schema.Delete(hash)
-	if _, err := i.marshalToCAS(ctx, del); err != nil {
+	delHash, err := i.marshalToCAS(ctx, del)
+	if err != nil {
 		return err
 	}
 
-	if err := i.index(ctx, *del, nil); err != nil {
+	if err := i.index(ctx, delHash, *del, nil); err != nil {
 		return err
 	}
 
@@ -139,6 +269,7 @@ func (i *Index) Delete(ctx context.Context, hash string) error {
 func (i *Index) GetPermanode(ctx context.Context, permanodeHash string, result Indexable) error {
 	sel := i.Q.
 		Where("permanode_hash = ?", permanodeHash).
+		Where(sq.NotEq{"content_hash": ""}).
 		OrderBy("timestamp DESC").
 		Limit(1)
 	if err := i.Get(ctx, result, sel); err != nil {
@@ -150,23 +281,35 @@ func (i *Index) GetPermanode(ctx context.Context, permanodeHash string, result I
 
 func (i *Index) UnmarshalContent(ctx context.Context, claim schema.Claim, contentHash string) (Indexable, error) {
 	var content Indexable
-	switch claim.SchemaKind {
-	case "ExtractRequest":
-		content = &rpc.ExtractRequest{}
-	case "ExtractResponse":
-		content = &rpc.ExtractResponse{}
-	case "PluginInstance":
-		content = &schema.PluginInstance{}
-	case "TransformRequest":
-		content = &rpc.TransformRequest{}
-	case "TransformResponse":
-		content = &rpc.TransformResponse{}
-	default:
-		return nil, fmt.Errorf("unknown schema kind: %s", claim.SchemaKind)
+	if hash := descriptorHash(claim); hash != "" {
+		if err := i.loadDescriptors(ctx, hash); err != nil {
+			return nil, err
+		}
+
+		if d, ok := newDynamicKind(hash, claim.SchemaKind); ok {
+			content = d
+		}
+	}
+
+	if content == nil {
+		var err error
+		if content, err = New(claim.SchemaKind); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get the content from CAS
-	if err := i.unmarshalFromCAS(ctx, claim.ContentHash, content); err != nil {
+	b, err := i.readFromCAS(ctx, claim.ContentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Upgrade content stored with an older version of the schema
+	if b, err = migrate(claim.SchemaKind, claim.SchemaVersion, schemaVersion(content), b); err != nil {
+		return nil, err
+	}
+
+	if err := canonical.Unmarshal(b, content); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
 	}
 
@@ -178,6 +321,15 @@ func (i *Index) Rebuild(ctx context.Context) error {
 		return fmt.Errorf("failed to drop table: %w", err)
 	}
 
+	if _, err := i.db.ExecContext(ctx, "DROP TABLE IF EXISTS edges"); err != nil {
+		return fmt.Errorf("failed to drop edges table: %w", err)
+	}
+
+	i.mu.Lock()
+	i.columns = nil
+	i.created = false
+	i.mu.Unlock()
+
 	// Get all hashes from CAS
 	hashes, err := i.cas.Iterate(ctx)
 	if err != nil {
@@ -199,7 +351,7 @@ func (i *Index) Rebuild(ctx context.Context) error {
 			return fmt.Errorf("failed to read CAS object: %w", err)
 		}
 
-		if !strings.HasPrefix(string(b), "{\"dataq_type\":") {
+		if !schema.IsClaim(b) {
 			log.Println("skipping non-claim object: ", hash)
 			continue
 		} else {
@@ -222,7 +374,7 @@ func (i *Index) Rebuild(ctx context.Context) error {
 			}
 			slog.Info("processing delete claim", "delete_hash", claim.DeleteHash)
 
-			if err := i.index(ctx, claim, nil); err != nil {
+			if err := i.index(ctx, hash, claim, nil); err != nil {
 				return fmt.Errorf("failed to index data: %w", err)
 			}
 
@@ -240,6 +392,13 @@ func (i *Index) Rebuild(ctx context.Context) error {
 			continue
 		}
 
+		if claim.Type == "data_source" {
+			if err := i.index(ctx, hash, claim, dataSourceContent(claim)); err != nil {
+				return fmt.Errorf("failed to index data source: %w", err)
+			}
+			continue
+		}
+
 		if claim.Type == "permanode_version" {
 			if claim.PermanodeHash == "" {
 				return fmt.Errorf("permanode version missing permanode hash")
@@ -249,7 +408,9 @@ func (i *Index) Rebuild(ctx context.Context) error {
 				return fmt.Errorf("permanode version missing timestamp")
 			}
 
-			sel := i.Q.Where("permanode_hash = ?", claim.PermanodeHash)
+			sel := i.Q.
+				Where("permanode_hash = ?", claim.PermanodeHash).
+				Where(sq.NotEq{"content_hash": ""})
 			claims, err := i.Query(ctx, sel)
 			if err != nil {
 				return fmt.Errorf("failed to get claims: %w", err)
@@ -266,8 +427,9 @@ func (i *Index) Rebuild(ctx context.Context) error {
 				}
 
 				if c.Timestamp.Equal(claim.Timestamp) || c.Timestamp.Before(claim.Timestamp) {
-					if _, err := sq.Delete("index_data").
+					if _, err := i.sb.Delete("index_data").
 						Where("content_hash = ?", c.ContentHash).
+						Where("permanode_hash = ?", claim.PermanodeHash).
 						RunWith(i.db).
 						Exec(); err != nil {
 						return fmt.Errorf("failed to delete index data: %w", err)
@@ -301,7 +463,7 @@ func (i *Index) Rebuild(ctx context.Context) error {
 			return fmt.Errorf("failed to unmarshal content: %w", err)
 		}
 
-		if err := i.index(ctx, claim, content); err != nil {
+		if err := i.index(ctx, hash, claim, content); err != nil {
 			return fmt.Errorf("failed to index data: %w", err)
 		}
 	}
@@ -312,185 +474,271 @@ func (i *Index) Rebuild(ctx context.Context) error {
 // Get retrieves a single object from the index and CAS store.
 // The caller must provide a concrete type T that implements Indexable.
 func (i *Index) Get(ctx context.Context, result Indexable, query sq.SelectBuilder) error {
-	query = query.RemoveColumns().Columns("schema_kind", "content_hash")
-	rows, err := query.RunWith(i.db).Query()
+	claims, err := i.Query(ctx, query)
 	if err != nil {
 		return fmt.Errorf("failed to query index: %w", err)
 	}
-	defer rows.Close()
-
-	var contentHash, schemaKind string
-	var found bool
-
-	for rows.Next() {
-		if found {
-			return fmt.Errorf("multiple records found for query")
-		}
-		if err := rows.Scan(&schemaKind, &contentHash); err != nil {
-			return fmt.Errorf("failed to scan row: %w", err)
-		}
-		found = true
+	// Versions of several permanodes can share content, it is still one record
+	claims = slices.CompactFunc(claims, func(a, b schema.Claim) bool {
+		return a.ContentHash == b.ContentHash
+	})
+	if len(claims) > 1 {
+		return fmt.Errorf("multiple records found for query")
 	}
-	if err = rows.Err(); err != nil {
-		return fmt.Errorf("failed to iterate rows: %w", err)
-	}
-	if !found {
+	if len(claims) == 0 {
 		return fmt.Errorf("no record found for query")
 	}
+	claim := claims[0]
 
 	// Verify schema kind matches
-	if schemaKind != result.SchemaKind() {
-		return fmt.Errorf("schema kind mismatch: stored %s, requested %s", schemaKind, result.SchemaKind())
+	if claim.SchemaKind != result.SchemaKind() {
+		return fmt.Errorf("schema kind mismatch: stored %s, requested %s", claim.SchemaKind, result.SchemaKind())
 	}
 
 	// Retrieve from CAS
-	return i.unmarshalFromCAS(ctx, contentHash, result)
+	b, err := i.readFromCAS(ctx, claim.ContentHash)
+	if err != nil {
+		return err
+	}
+
+	if b, err = migrate(claim.SchemaKind, claim.SchemaVersion, schemaVersion(result), b); err != nil {
+		return err
+	}
+
+	if err := canonical.Unmarshal(b, result); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+	return nil
 }
 
 // IterateFields returns a sequence of field names for the index_data table.
+// The field names are cached after the first successful lookup.
 func (i *Index) IterateFields(ctx context.Context) iter.Seq2[string, error] {
 	return func(yield func(string, error) bool) {
-		rows, err := i.db.QueryContext(ctx, "PRAGMA table_info(index_data)")
+		columns, err := i.Columns(ctx)
 		if err != nil {
-			// For demonstration, we panic on error.
-			// In real code, you might want a different mechanism.
-			yield("", fmt.Errorf("failed to query table info: %w", err))
+			yield("", err)
 			return
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var cid int
-			var name, type_ string
-			var notnull, pk int
-			var dflt_value interface{}
-
-			if err := rows.Scan(&cid, &name, &type_, &notnull, &dflt_value, &pk); err != nil {
-				yield("", err)
-				return
-			}
 
+		for _, name := range columns {
 			if !yield(name, nil) {
 				return
 			}
 		}
+	}
+}
 
-		// Check for iteration error
-		if rowsErr := rows.Err(); rowsErr != nil {
-			yield("", rowsErr)
-			return
+// Columns returns the columns of the index_data table, or nil if the table
+// does not exist yet.
+func (i *Index) Columns(ctx context.Context) ([]string, error) {
+	i.mu.RLock()
+	columns := i.columns
+	fresh := time.Since(i.columnsAt) < columnsTTL
+	i.mu.RUnlock()
+	if columns != nil && fresh {
+		return columns, nil
+	}
+
+	all, err := i.backend.Columns(ctx, i.db)
+	if err != nil {
+		return nil, err
+	}
+
+	columns = nil
+	for _, name := range all {
+		if !strings.HasPrefix(name, "_") {
+			columns = append(columns, name)
 		}
 	}
+
+	// Don't cache a missing table, it is created by the first index call
+	if len(columns) > 0 {
+		i.mu.Lock()
+		i.columns = columns
+		i.columnsAt = time.Now()
+		i.mu.Unlock()
+	}
+
+	return columns, nil
+}
+
+// resetColumns clears the cached column list after the table changes.
+func (i *Index) resetColumns() {
+	i.mu.Lock()
+	i.columns = nil
+	i.mu.Unlock()
 }
 
 // Query executes a SQL query against the index and returns matching rows
 // The query should be a valid SQL WHERE clause
 func (i *Index) Query(ctx context.Context, query sq.SelectBuilder) ([]schema.Claim, error) {
-	var columns []string
-	for name, err := range i.IterateFields(ctx) {
+	var results []schema.Claim
+	for claim, err := range i.QueryIter(ctx, query) {
 		if err != nil {
 			return nil, err
 		}
 
-		columns = append(columns, name)
+		results = append(results, claim)
 	}
 
-	// If columns is zero, it means that the table does not exist yet.
-	if len(columns) == 0 {
-		return nil, nil
-	}
-	rows, err := query.RunWith(i.db).Query()
-	if err != nil {
-		if strings.Contains(err.Error(), "no such column") {
-			return nil, nil
-		}
+	return results, nil
+}
 
-		return nil, fmt.Errorf("failed to execute query (%v): %w", query, err)
+// QueryIter executes a SQL query against the index and yields matching rows
+// as they are read, without loading the whole result into memory.
+func (i *Index) QueryIter(ctx context.Context, query sq.SelectBuilder) iter.Seq2[schema.Claim, error] {
+	return func(yield func(schema.Claim, error) bool) {
+		for row, err := range i.queryRows(ctx, query) {
+			if !yield(row.claim, err) {
+				return
+			}
+		}
 	}
-	defer rows.Close()
+}
 
-	var results []schema.Claim
-	for rows.Next() {
-		// Create a slice of interface{} to scan into
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
+// row is a scanned index_data row along with its rowid, when selected.
+type row struct {
+	claim schema.Claim
+	rowid int64
+}
+
+func (i *Index) queryRows(ctx context.Context, query sq.SelectBuilder) iter.Seq2[row, error] {
+	return func(yield func(row, error) bool) {
+		columns, err := i.Columns(ctx)
+		if err != nil {
+			yield(row{}, err)
+			return
 		}
 
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+		// If columns is zero, it means that the table does not exist yet.
+		if len(columns) == 0 {
+			return
 		}
 
-		// Create result object
-		result := schema.Claim{
-			Metadata: make(map[string]interface{}),
+		rows, err := query.RunWith(i.db).QueryContext(ctx)
+		if err != nil {
+			if i.backend.UndefinedColumn(err) {
+				return
+			}
+
+			yield(row{}, fmt.Errorf("failed to execute query (%v): %w", query, err))
+			return
 		}
+		defer rows.Close()
 
-		// Map values to appropriate fields
-		for i, col := range columns {
-			val := values[i]
-			switch col {
-			case "schema_kind":
-				if v, ok := val.(string); ok {
-					result.SchemaKind = v
-				}
-			case "content_hash":
-				if v, ok := val.(string); ok {
-					result.ContentHash = v
-				}
-			case "permanode_hash":
-				if v, ok := val.(string); ok {
-					result.PermanodeHash = v
-				}
-			case "timestamp":
-				if v, ok := val.(int64); ok {
-					result.Timestamp = time.UnixMilli(v)
-				}
-			case "delete_hash":
-				if v, ok := val.(string); ok {
-					result.DeleteHash = v
-				}
-			default:
-				result.Metadata[col] = val
+		names, err := rows.Columns()
+		if err != nil {
+			yield(row{}, fmt.Errorf("failed to get columns: %w", err))
+			return
+		}
+
+		for rows.Next() {
+			// Create a slice of interface{} to scan into
+			values := make([]interface{}, len(names))
+			valuePtrs := make([]interface{}, len(names))
+			for i := range values {
+				valuePtrs[i] = &values[i]
+			}
+
+			if err := rows.Scan(valuePtrs...); err != nil {
+				yield(row{}, fmt.Errorf("failed to scan row: %w", err))
+				return
+			}
+
+			if !yield(scanClaim(names, values), nil) {
+				return
 			}
 		}
 
-		results = append(results, result)
+		if err = rows.Err(); err != nil {
+			yield(row{}, fmt.Errorf("rows.Err() would like to speak with you: %w", err))
+		}
 	}
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows.Err() would like to speak with you: %w", err)
+func scanClaim(columns []string, values []interface{}) row {
+	var r row
+
+	// Create result object
+	result := schema.Claim{
+		Metadata: make(map[string]interface{}),
 	}
 
-	return results, nil
+	// Map values to appropriate fields
+	for i, col := range columns {
+		val := values[i]
+		switch col {
+		case "schema_kind":
+			if v, ok := val.(string); ok {
+				result.SchemaKind = v
+			}
+		case "content_hash":
+			if v, ok := val.(string); ok {
+				result.ContentHash = v
+			}
+		case "permanode_hash":
+			if v, ok := val.(string); ok {
+				result.PermanodeHash = v
+			}
+		case "timestamp":
+			if v, ok := val.(int64); ok {
+				result.Timestamp = time.UnixMilli(v)
+			}
+		case "delete_hash":
+			if v, ok := val.(string); ok {
+				result.DeleteHash = v
+			}
+		case "schema_version":
+			if v, ok := val.(int64); ok {
+				result.SchemaVersion = int(v)
+			}
+		case rowidColumn:
+			if v, ok := val.(int64); ok {
+				r.rowid = v
+			}
+		default:
+			// Internal backend columns
+			if strings.HasPrefix(col, "_") {
+				continue
+			}
+			result.Metadata[col] = val
+		}
+	}
+
+	r.claim = result
+	return r
 }
 
 // unmarshalFromCAS reads and unmarshals data from CAS storage into the provided object
 func (i *Index) unmarshalFromCAS(ctx context.Context, contentHash string, result any) error {
-	r, err := i.cas.Retrieve(ctx, contentHash)
+	b, err := i.readFromCAS(ctx, contentHash)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve CAS object: %w", err)
+		return err
 	}
-	defer r.Close()
 
-	b, err := io.ReadAll(r)
-	if err != nil {
-		return fmt.Errorf("failed to read CAS object: %w", err)
+	if err := canonical.Unmarshal(b, result); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
 	}
+	return nil
+}
 
-	if presult, ok := result.(IndexableProto); ok {
-		err = protojson.Unmarshal(b, presult)
-	} else {
-		err = json.Unmarshal(b, result)
+func (i *Index) readFromCAS(ctx context.Context, hash string) ([]byte, error) {
+	r, err := i.cas.Retrieve(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve CAS object: %w", err)
 	}
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal data: %w", err)
+		return nil, fmt.Errorf("failed to read CAS object: %w", err)
 	}
-	return nil
+
+	return b, nil
 }
 
-func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) error {
+func (i *Index) index(ctx context.Context, claimHash string, claim schema.Claim, data Indexable) error {
 	var metadata map[string]interface{}
 	var schemaKind string
 
@@ -505,19 +753,16 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 		return fmt.Errorf("data cannot be nil for non-delete claims")
 	}
 
-	// Create base table if not exists
-	// it is possible to index and store content that is not part of a permanode.
-	// This content cannot be edited
-	createTableSQL := `CREATE TABLE IF NOT EXISTS index_data (
-		schema_kind TEXT NOT NULL,
-		permanode_hash TEXT,
-		timestamp INTEGER,
-		content_hash TEXT,
-		delete_hash TEXT
-	)`
+	// Versions of permanodes managed by a plugin can be queried by the
+	// instance they came from
+	if claim.Type == "permanode_version" && claim.PluginID != "" {
+		metadata = maps.Clone(metadata)
+		metadata["instance_hash"] = claim.PluginID
+	}
 
-	if _, err := i.db.Exec(createTableSQL); err != nil {
-		return fmt.Errorf("failed to create index table: %w", err)
+	// Create base tables if not exists
+	if err := i.createTables(ctx); err != nil {
+		return err
 	}
 
 	// Get existing columns
@@ -530,36 +775,30 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 		existingColumns[name] = true
 	}
 
+	// Indexes created before versions were recorded don't have the column
+	if claim.SchemaVersion != 0 && !existingColumns["schema_version"] {
+		if err := i.backend.AddColumn(ctx, i.db, "schema_version", int64(0)); err != nil {
+			return err
+		}
+		i.resetColumns()
+	}
+
 	// Add new columns as needed
 	for key, value := range metadata {
 		if existingColumns[key] {
 			continue
 		}
 
-		var colType string
-		switch value.(type) {
-		case int, int32, int64:
-			colType = "INTEGER"
-		case float32, float64:
-			colType = "REAL"
-		case bool:
-			colType = "BOOLEAN"
-		case string:
-			colType = "TEXT"
-		default:
-			colType = "TEXT"
-		}
-
-		alterSQL := "ALTER TABLE index_data ADD COLUMN " + key + " " + colType
-		if _, err := i.db.Exec(alterSQL); err != nil {
-			return fmt.Errorf("failed to add column: %w (%s)", err, alterSQL)
+		if err := i.backend.AddColumn(ctx, i.db, key, value); err != nil {
+			return err
 		}
+		i.resetColumns()
 	}
 
 	var values []interface{}
 
 	// Build insert statement using Squirrel
-	insertBuilder := sq.Insert("index_data").
+	insertBuilder := i.sb.Insert("index_data").
 		Columns("schema_kind", "permanode_hash", "content_hash")
 	values = append(values, schemaKind, claim.PermanodeHash, claim.ContentHash)
 
@@ -573,6 +812,11 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 		values = append(values, claim.DeleteHash)
 	}
 
+	if claim.SchemaVersion != 0 {
+		insertBuilder = insertBuilder.Columns("schema_version")
+		values = append(values, claim.SchemaVersion)
+	}
+
 	for key, value := range metadata {
 		switch v := value.(type) {
 		case int, int32, int64, float32, float64, bool, string:
@@ -585,6 +829,15 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 			}
 			value = string(b)
 		}
+		insertBuilder = insertBuilder.Columns(QuoteIdent(key))
+		values = append(values, value)
+	}
+
+	extra, err := i.backend.Extra(metadata)
+	if err != nil {
+		return err
+	}
+	for key, value := range extra {
 		insertBuilder = insertBuilder.Columns(key)
 		values = append(values, value)
 	}
@@ -598,7 +851,7 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 		}
 
 		// First delete existing entries if the delete claim is newer
-		_, err := sq.Delete("index_data").
+		_, err := i.sb.Delete("index_data").
 			Where(sq.Or{
 				sq.Eq{"content_hash": claim.DeleteHash},
 				sq.Eq{"permanode_hash": claim.DeleteHash},
@@ -612,26 +865,49 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 		if err != nil {
 			return fmt.Errorf("failed to delete entries: %w", err)
 		}
-	} else {
-		// Check if content_hash already exists
-		var contentHash string
-		err := sq.Select("content_hash").
-			From("index_data").
-			Where(sq.Eq{"content_hash": claim.ContentHash}).
+
+		// Deleted objects no longer reference anything, and aren't referenced.
+		// The edges of the versions of a deleted permanode go with it.
+		versions := sq.Select("claim_hash").
+			From("edges").
+			Where(sq.Eq{"to_hash": claim.DeleteHash}).
+			Where(sq.Eq{"relation": "permanode"})
+		if _, err := i.sb.Delete("edges").
+			Where(sq.Or{
+				sq.Expr("claim_hash IN (?)", versions),
+				sq.Eq{"from_hash": claim.DeleteHash},
+				sq.Eq{"to_hash": claim.DeleteHash},
+			}).
 			RunWith(i.db).
-			QueryRow().
-			Scan(&contentHash)
-		if err != nil && err != sql.ErrNoRows {
-			return fmt.Errorf("failed to check for existing content: %w", err)
+			Exec(); err != nil {
+			return fmt.Errorf("failed to delete edges: %w", err)
 		}
-		if err != sql.ErrNoRows {
-			slog.Warn("content hash already exists in index", "hash", claim.ContentHash)
-			return nil
+	} else {
+		// Check if content_hash already exists. Data source claims don't
+		// reference content. Permanodes can have the same content, e.g. the
+		// same contact of two accounts, so versions are only skipped if their
+		// own permanode has it.
+		if claim.ContentHash != "" {
+			var contentHash string
+			err := i.sb.Select("content_hash").
+				From("index_data").
+				Where(sq.Eq{"content_hash": claim.ContentHash}).
+				Where(sq.Eq{"permanode_hash": claim.PermanodeHash}).
+				RunWith(i.db).
+				QueryRow().
+				Scan(&contentHash)
+			if err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("failed to check for existing content: %w", err)
+			}
+			if err != sql.ErrNoRows {
+				slog.Warn("content hash already exists in index", "hash", claim.ContentHash)
+				return nil
+			}
 		}
 
 		// Check if there's a newer delete claim for this content or permanode
 		var deleteTimestamp int64
-		err = sq.Select("timestamp").
+		err := i.sb.Select("timestamp").
 			From("index_data").
 			Where(sq.And{
 				sq.Or{
@@ -653,25 +929,46 @@ func (i *Index) index(ctx context.Context, claim schema.Claim, data Indexable) e
 		}
 	}
 
-	_, err := insertBuilder.RunWith(i.db).Exec()
-	return err
+	if err := i.insertEdges(ctx, claimEdges(claimHash, claim, metadata)); err != nil {
+		return err
+	}
+
+	if _, err := insertBuilder.RunWith(i.db).Exec(); err != nil {
+		return err
+	}
+
+	return i.recordChange(ctx, claimHash, schemaKind, claim)
+}
+
+// createTables creates the index tables once, or again after a rebuild.
+func (i *Index) createTables(ctx context.Context) error {
+	i.mu.RLock()
+	created := i.created
+	i.mu.RUnlock()
+	if created {
+		return nil
+	}
+
+	if err := i.backend.CreateTables(ctx, i.db); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	i.created = true
+	i.mu.Unlock()
+
+	return nil
+}
+
+// QuoteIdent quotes a column name for use in SQL. Metadata keys come from
+// schema field names, which may collide with keywords such as "from".
+func QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
 }
 
 // marshalToCAS marshals the provided object and stores it in CAS storage
 func (i *Index) marshalToCAS(ctx context.Context, data any) (string, error) {
-	var b []byte
-	var err error
-
-	if pdata, ok := data.(IndexableProto); ok {
-		// Trying to make this as deterministic as possible
-		b, err = protojson.MarshalOptions{
-			Multiline:     true,
-			Indent:        "\t",
-			UseProtoNames: true,
-		}.Marshal(pdata)
-	} else {
-		b, err = json.Marshal(data)
-	}
+	b, err := canonical.Marshal(data)
 	if err != nil {
 		return "", err
 	}
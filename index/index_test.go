@@ -0,0 +1,102 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"go.quinn.io/dataq/cas"
+)
+
+// envTestPostgres is the DSN of a PostgreSQL database to run the backend tests
+// against, e.g. the one `task postgres` starts. The Postgres tests are skipped
+// when it is unset.
+const envTestPostgres = "DATAQ_TEST_POSTGRES"
+
+// forEachBackend runs f with an empty index on every backend available.
+func forEachBackend(t *testing.T, f func(t *testing.T, idx *Index)) {
+	t.Helper()
+
+	t.Run("sqlite", func(t *testing.T) {
+		f(t, newTestIndex(t))
+	})
+	t.Run("postgres", func(t *testing.T) {
+		f(t, newTestPostgresIndex(t))
+	})
+}
+
+// newTestIndex returns an index in a new in-memory SQLite database.
+func newTestIndex(t *testing.T) *Index {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	// Each connection to :memory: is a new database
+	db.SetMaxOpenConns(1)
+
+	return NewIndex(cas.NewMemory(), db)
+}
+
+var testSchemas atomic.Int64
+
+// newTestPostgresIndex returns an index in a new schema of the database at
+// envTestPostgres, dropped when the test ends.
+func newTestPostgresIndex(t *testing.T) *Index {
+	t.Helper()
+
+	dsn := os.Getenv(envTestPostgres)
+	if dsn == "" {
+		t.Skipf("%s is not set", envTestPostgres)
+	}
+
+	ctx := context.Background()
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("dataq_test_%d_%d", time.Now().UnixNano(), testSchemas.Add(1))
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("failed to drop schema: %v", err)
+		}
+	})
+
+	// Every connection uses the schema
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			t.Fatalf("invalid %s: %v", envTestPostgres, err)
+		}
+		q := u.Query()
+		q.Set("search_path", schema)
+		u.RawQuery = q.Encode()
+		dsn = u.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewIndexWithBackend(cas.NewMemory(), db, Postgres{})
+}
@@ -0,0 +1,159 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// LineageStep is a single object in the provenance chain of a piece of content.
+type LineageStep struct {
+	Hash       string `json:"hash"`
+	SchemaKind string `json:"schema_kind,omitempty"`
+
+	// InstanceHash is the address of the PluginInstance the object was
+	// extracted or transformed for
+	InstanceHash string `json:"instance_hash,omitempty"`
+
+	// Relation is how the previous step references this one
+	Relation string `json:"relation,omitempty"`
+}
+
+// lineageRelations are followed in order of preference when walking back
+// from a piece of content towards the extract that produced it.
+var lineageRelations = []string{"transform_response", "request", "parent"}
+
+// Lineage returns the chain of objects that produced hash, starting at hash and
+// ending with the initial ExtractRequest and the plugin instance it was sent to.
+// hash may be a permanode, in which case the chain starts at its latest version.
+func (i *Index) Lineage(ctx context.Context, hash string) ([]LineageStep, error) {
+	var steps []LineageStep
+
+	versions, err := i.Query(ctx, i.Q.
+		Where(sq.Eq{"permanode_hash": hash}).
+		Where(sq.NotEq{"content_hash": ""}).
+		OrderBy("timestamp DESC").
+		Limit(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query permanode versions: %w", err)
+	}
+	if len(versions) > 0 {
+		steps = append(steps, LineageStep{Hash: hash, SchemaKind: versions[0].SchemaKind})
+		hash = versions[0].ContentHash
+	}
+
+	visited := make(map[string]bool)
+	relation := ""
+	if len(steps) > 0 {
+		relation = "content"
+	}
+	var instance string
+
+	for hash != "" && !visited[hash] {
+		visited[hash] = true
+
+		step, err := i.lineageStep(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		step.Relation = relation
+		steps = append(steps, step)
+
+		if step.InstanceHash != "" {
+			instance = step.InstanceHash
+		}
+
+		hash, relation, err = i.lineageNext(ctx, step)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if instance != "" {
+		plugins, err := i.Query(ctx, i.Q.
+			Where(sq.Eq{"permanode_hash": instance}).
+			Where(sq.Eq{"schema_kind": "PluginInstance"}).
+			Limit(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query plugin instance: %w", err)
+		}
+		if len(plugins) > 0 {
+			steps = append(steps, LineageStep{
+				Hash:       instance,
+				SchemaKind: "PluginInstance",
+				Relation:   "plugin",
+			})
+		}
+	}
+
+	return steps, nil
+}
+
+func (i *Index) lineageStep(ctx context.Context, hash string) (LineageStep, error) {
+	step := LineageStep{Hash: hash}
+
+	claims, err := i.Query(ctx, i.Q.Where(sq.Eq{"content_hash": hash}).Limit(1))
+	if err != nil {
+		return step, fmt.Errorf("failed to query content: %w", err)
+	}
+	if len(claims) > 0 {
+		step.SchemaKind = claims[0].SchemaKind
+		// Requests stored before version 2 named the instance plugin_id
+		for _, key := range []string{"instance_hash", "plugin_id"} {
+			if instance, ok := claims[0].Metadata[key].(string); ok && instance != "" {
+				step.InstanceHash = instance
+				break
+			}
+		}
+	}
+
+	return step, nil
+}
+
+// lineageNext returns the hash and relation of the object that produced step.
+func (i *Index) lineageNext(ctx context.Context, step LineageStep) (string, string, error) {
+	edges, err := i.queryEdges(ctx, i.sb.Select("from_hash", "to_hash", "relation", "claim_hash").
+		From("edges").
+		Where(sq.Eq{"from_hash": step.Hash}))
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, relation := range lineageRelations {
+		for _, e := range edges {
+			if e.Relation == relation {
+				return e.To, relation, nil
+			}
+		}
+	}
+
+	// A TransformRequest references the extracted data rather than the
+	// ExtractResponse, so find the response that stored the same data.
+	for _, e := range edges {
+		if e.Relation != "data" {
+			continue
+		}
+
+		var from string
+		err := i.sb.Select("e.from_hash").
+			From("edges e").
+			Join("index_data d ON d.content_hash = e.from_hash").
+			Where(sq.Eq{"e.to_hash": e.To, "e.relation": "data", "d.schema_kind": "ExtractResponse"}).
+			Limit(1).
+			RunWith(i.db).
+			QueryRowContext(ctx).
+			Scan(&from)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to query extract response: %w", err)
+		}
+
+		return from, "data", nil
+	}
+
+	return "", "", nil
+}
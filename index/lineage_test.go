@@ -0,0 +1,104 @@
+package index
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+)
+
+func TestLineage(t *testing.T) {
+	tests := []struct {
+		name string
+		// legacy moves the instance of the requests to the plugin_id column
+		// they had before version 2
+		legacy  bool
+		rebuild bool
+	}{
+		{name: "instance_hash"},
+		{name: "rebuild", rebuild: true},
+		{name: "plugin_id", legacy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachBackend(t, func(t *testing.T, idx *Index) {
+				testLineage(t, idx, tt.legacy, tt.rebuild)
+			})
+		})
+	}
+}
+
+func testLineage(t *testing.T, idx *Index, legacy, rebuild bool) {
+	ctx := context.Background()
+	must := func(hash string, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+
+	instance := must(idx.CreatePermanode(ctx, &schema.PluginInstance{PluginID: "test", Label: "test"}))
+	extractReq := must(idx.Store(ctx, &rpc.ExtractRequest{InstanceHash: instance, Kind: "list"}))
+	data := must(idx.cas.Store(ctx, strings.NewReader(`{"name":"alice"}`)))
+	extractRes := must(idx.Store(ctx, &rpc.ExtractResponse{
+		Kind:        "contact",
+		RequestHash: extractReq,
+		Data:        &rpc.ExtractResponse_Hash{Hash: data},
+	}))
+	transformReq := must(idx.Store(ctx, &rpc.TransformRequest{
+		InstanceHash: instance,
+		Kind:         "contact",
+		Data:         &rpc.TransformRequest_Hash{Hash: data},
+	}))
+	transformRes := must(idx.Store(ctx, &rpc.TransformResponse{Kind: "contact", RequestHash: transformReq}))
+	permanode := must(idx.CreateDataSource(ctx, instance, "alice", transformRes, &rpc.Contact{Name: "alice"}))
+
+	if legacy {
+		// Data sources already added the plugin_id column
+		if _, err := idx.db.ExecContext(ctx, `UPDATE index_data
+					SET plugin_id = instance_hash, instance_hash = NULL
+					WHERE schema_kind IN ('ExtractRequest', 'TransformRequest')`); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rebuild {
+		if err := idx.Rebuild(ctx); err != nil {
+			t.Fatalf("Rebuild: %v", err)
+		}
+	}
+
+	steps, err := idx.Lineage(ctx, permanode)
+	if err != nil {
+		t.Fatalf("Lineage: %v", err)
+	}
+
+	want := []struct {
+		hash, kind, relation string
+	}{
+		{permanode, "Contact", ""},
+		{"", "Contact", "content"},
+		{transformRes, "TransformResponse", "transform_response"},
+		{transformReq, "TransformRequest", "request"},
+		{extractRes, "ExtractResponse", "data"},
+		{extractReq, "ExtractRequest", "request"},
+		{instance, "PluginInstance", "plugin"},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("Lineage = %+v, want %d steps", steps, len(want))
+	}
+	for n, w := range want {
+		step := steps[n]
+		if (w.hash != "" && step.Hash != w.hash) || step.SchemaKind != w.kind || step.Relation != w.relation {
+			t.Errorf("step %d = %+v, want %s %s %s", n, step, w.hash, w.kind, w.relation)
+		}
+	}
+	for _, n := range []int{3, 5} {
+		if steps[n].InstanceHash != instance {
+			t.Errorf("step %d instance = %q, want %q", n, steps[n].InstanceHash, instance)
+		}
+	}
+}
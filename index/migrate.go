@@ -0,0 +1,282 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Migration upgrades the JSON content of a kind by one schema version. Keys
+// are the proto field names, e.g. message_id.
+type Migration func(content map[string]interface{}) (map[string]interface{}, error)
+
+// migrations maps schema kinds to the migrations from each version
+var migrations = struct {
+	sync.RWMutex
+	kinds map[string]map[int]Migration
+}{
+	kinds: make(map[string]map[int]Migration),
+}
+
+// renames maps schema kinds to the index columns renamed by a migration, from
+// the old name to the new one
+var renames = struct {
+	sync.RWMutex
+	kinds map[string]map[string]string
+}{
+	kinds: make(map[string]map[string]string),
+}
+
+// RegisterRename records that a migration of kind renamed the field indexed as
+// column from to to. Content is indexed when it is stored, so Migrate copies the
+// column of the rows indexed before.
+func RegisterRename(kind, from, to string) {
+	renames.Lock()
+	defer renames.Unlock()
+
+	if renames.kinds[kind] == nil {
+		renames.kinds[kind] = make(map[string]string)
+	}
+	renames.kinds[kind][from] = to
+}
+
+// RegisterMigration registers the migration of kind from version from to
+// from+1. Content stored with an older version is migrated when it is read.
+func RegisterMigration(kind string, from int, m Migration) {
+	migrations.Lock()
+	defer migrations.Unlock()
+
+	if migrations.kinds[kind] == nil {
+		migrations.kinds[kind] = make(map[int]Migration)
+	}
+	migrations.kinds[kind][from] = m
+}
+
+// MigrationKinds returns the kinds with registered migrations.
+func MigrationKinds() []string {
+	migrations.RLock()
+	defer migrations.RUnlock()
+
+	kinds := make([]string, 0, len(migrations.kinds))
+	for kind := range migrations.kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// schemaVersion returns the current schema version of v.
+func schemaVersion(v any) int {
+	if versioned, ok := v.(Versioned); ok {
+		return versioned.SchemaVersion()
+	}
+	return 1
+}
+
+// migrate upgrades content of kind stored with version from to version to.
+// Content stored before versions were recorded is version 1.
+func migrate(kind string, from, to int, b []byte) ([]byte, error) {
+	if from == 0 {
+		from = 1
+	}
+	if from >= to {
+		return b, nil
+	}
+
+	var content map[string]interface{}
+	if err := json.Unmarshal(b, &content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s version %d: %w", kind, from, err)
+	}
+
+	migrations.RLock()
+	defer migrations.RUnlock()
+
+	for v := from; v < to; v++ {
+		m, ok := migrations.kinds[kind][v]
+		if !ok {
+			return nil, fmt.Errorf("no migration of %s from version %d", kind, v)
+		}
+
+		var err error
+		if content, err = m(content); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s from version %d: %w", kind, v, err)
+		}
+	}
+
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s version %d: %w", kind, to, err)
+	}
+	return b, nil
+}
+
+// Migrate writes a new version of every permanode of kind whose latest content
+// was stored with an older schema version. The old versions are kept. It
+// returns the number of permanodes migrated.
+func (i *Index) Migrate(ctx context.Context, kind string) (int, error) {
+	content, err := New(kind)
+	if err != nil {
+		return 0, err
+	}
+	version := schemaVersion(content)
+
+	if err := i.renameColumns(ctx, kind); err != nil {
+		return 0, err
+	}
+
+	claims, err := i.Query(ctx, i.Q.
+		Where(sq.Eq{"schema_kind": kind}).
+		Where(sq.NotEq{"permanode_hash": ""}).
+		Where(sq.NotEq{"content_hash": ""}).
+		OrderBy("timestamp DESC"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s: %w", kind, err)
+	}
+	if len(claims) == 0 {
+		return 0, nil
+	}
+
+	// Indexes created before versions were recorded don't have the column
+	columns, err := i.Columns(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(columns, "schema_version") {
+		if err := i.backend.AddColumn(ctx, i.db, "schema_version", int64(0)); err != nil {
+			return 0, err
+		}
+		i.resetColumns()
+	}
+
+	var migrated int
+	seen := make(map[string]bool)
+	for _, claim := range claims {
+		// Only the latest version of a permanode is migrated
+		if seen[claim.PermanodeHash] {
+			continue
+		}
+		seen[claim.PermanodeHash] = true
+
+		if claim.SchemaVersion >= version {
+			continue
+		}
+
+		content, err := i.UnmarshalContent(ctx, claim, claim.ContentHash)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to read %s: %w", claim.ContentHash, err)
+		}
+
+		contentHash, err := i.marshalToCAS(ctx, content)
+		if err != nil {
+			return migrated, err
+		}
+
+		// Content that already has the current shape can't be stored again
+		// under the same hash, record its version instead
+		if contentHash == claim.ContentHash {
+			if _, err := i.sb.Update("index_data").
+				Set("schema_version", version).
+				Where(sq.Eq{"content_hash": claim.ContentHash}).
+				RunWith(i.db).
+				ExecContext(ctx); err != nil {
+				return migrated, fmt.Errorf("failed to update schema version: %w", err)
+			}
+			continue
+		}
+
+		// Keep the lineage of the content
+		src, err := i.source(ctx, claim.PermanodeHash, claim.ContentHash)
+		if err != nil {
+			return migrated, err
+		}
+
+		if _, err := i.updatePermanode(ctx, claim.PermanodeHash, src, content); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// renameColumns copies the renamed columns of the rows of kind indexed under
+// their old name.
+func (i *Index) renameColumns(ctx context.Context, kind string) error {
+	renames.RLock()
+	columns := renames.kinds[kind]
+	renames.RUnlock()
+	if len(columns) == 0 {
+		return nil
+	}
+
+	existing, err := i.Columns(ctx)
+	if err != nil {
+		return err
+	}
+
+	for from, to := range columns {
+		if !slices.Contains(existing, from) {
+			continue
+		}
+		if !slices.Contains(existing, to) {
+			if err := i.backend.AddColumn(ctx, i.db, to, ""); err != nil {
+				return err
+			}
+			i.resetColumns()
+		}
+
+		if _, err := i.sb.Update("index_data").
+			Set(QuoteIdent(to), sq.Expr(QuoteIdent(from))).
+			Where(sq.Eq{"schema_kind": kind}).
+			Where(sq.Eq{QuoteIdent(to): nil}).
+			Where(sq.NotEq{QuoteIdent(from): nil}).
+			RunWith(i.db).
+			ExecContext(ctx); err != nil {
+			return fmt.Errorf("failed to rename %s column %s to %s: %w", kind, from, to, err)
+		}
+	}
+
+	return nil
+}
+
+// source returns where the content at contentHash of a permanode came from.
+func (i *Index) source(ctx context.Context, permanodeHash, contentHash string) (source, error) {
+	var src source
+	var err error
+	if src.transformResponseHash, err = i.transformResponse(ctx, contentHash); err != nil {
+		return src, err
+	}
+
+	sources, err := i.Query(ctx, i.Q.
+		Where(sq.Eq{"schema_kind": "DataSource"}).
+		Where(sq.Eq{"permanode_hash": permanodeHash}).
+		Limit(1))
+	if err != nil {
+		return src, fmt.Errorf("failed to query data source: %w", err)
+	}
+	if len(sources) > 0 {
+		src.instance, _ = sources[0].Metadata["plugin_id"].(string)
+		src.key, _ = sources[0].Metadata["plugin_key"].(string)
+	}
+
+	return src, nil
+}
+
+// transformResponse returns the TransformResponse that produced contentHash.
+func (i *Index) transformResponse(ctx context.Context, contentHash string) (string, error) {
+	edges, err := i.queryEdges(ctx, i.sb.Select("from_hash", "to_hash", "relation", "claim_hash").
+		From("edges").
+		Where(sq.Eq{"from_hash": contentHash, "relation": "transform_response"}))
+	if err != nil {
+		return "", err
+	}
+	if len(edges) == 0 {
+		return "", nil
+	}
+	return edges[0].To, nil
+}
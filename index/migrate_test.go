@@ -0,0 +1,202 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func init() {
+	// A kind for the migration chain tests: version 1 had n, version 2 adds
+	// doubled and version 3 renames n to count.
+	RegisterMigration("migrateTest", 1, func(content map[string]interface{}) (map[string]interface{}, error) {
+		n, ok := content["n"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("n is not a number")
+		}
+		content["doubled"] = n * 2
+		return content, nil
+	})
+	RegisterMigration("migrateTest", 2, renameField("n", "count"))
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		from, to int
+		in       string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "unversioned",
+			kind: "migrateTest",
+			from: 0, to: 3,
+			in:   `{"n":2}`,
+			want: `{"count":2,"doubled":4}`,
+		},
+		{
+			name: "chain",
+			kind: "migrateTest",
+			from: 1, to: 3,
+			in:   `{"n":2,"other":"kept"}`,
+			want: `{"count":2,"doubled":4,"other":"kept"}`,
+		},
+		{
+			name: "partial",
+			kind: "migrateTest",
+			from: 2, to: 3,
+			in:   `{"n":2}`,
+			want: `{"count":2}`,
+		},
+		{
+			name: "current",
+			kind: "migrateTest",
+			from: 3, to: 3,
+			in:   `{"n":2}`,
+			want: `{"n":2}`,
+		},
+		{
+			name: "newer",
+			kind: "migrateTest",
+			from: 4, to: 3,
+			in:   `{"count":2}`,
+			want: `{"count":2}`,
+		},
+		{
+			name: "missing migration",
+			kind: "migrateTest",
+			from: 1, to: 4,
+			in:      `{"n":2}`,
+			wantErr: true,
+		},
+		{
+			name: "failed migration",
+			kind: "migrateTest",
+			from: 1, to: 3,
+			in:      `{"n":"two"}`,
+			wantErr: true,
+		},
+		{
+			name: "invalid content",
+			kind: "migrateTest",
+			from: 1, to: 3,
+			in:      `[]`,
+			wantErr: true,
+		},
+		{
+			name: "unknown kind",
+			kind: "Unknown",
+			from: 1, to: 2,
+			in:      `{}`,
+			wantErr: true,
+		},
+		{
+			name: "plugin_id",
+			kind: "ExtractRequest",
+			from: 1, to: 2,
+			in:   `{"plugin_id":"sha224-1","kind":"list"}`,
+			want: `{"instance_hash":"sha224-1","kind":"list"}`,
+		},
+		{
+			name: "plugin_id and instance_hash",
+			kind: "TransformRequest",
+			from: 1, to: 2,
+			in:   `{"plugin_id":"sha224-1","instance_hash":"sha224-2"}`,
+			want: `{"instance_hash":"sha224-2"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrate(tt.kind, tt.from, tt.to, []byte(tt.in))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("migrate = %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestMigrateEmailV1(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "addresses",
+			in:   `{"from":"Alice <alice@example.com>","to":"bob@example.com, Carol <carol@example.com>","cc":"","bcc":"not an address"}`,
+			want: `{"from":{"name":"Alice","email":"alice@example.com"},"to":[{"email":"bob@example.com"},{"name":"Carol","email":"carol@example.com"}],"bcc":[{"name":"not an address"}]}`,
+		},
+		{
+			name: "date",
+			in:   `{"date":"Mon, 02 Jan 2006 15:04:05 -0700"}`,
+			want: `{"date":"2006-01-02T22:04:05Z"}`,
+		},
+		{
+			name: "invalid date",
+			in:   `{"date":"yesterday"}`,
+			want: `{}`,
+		},
+		{
+			name: "bodies",
+			in:   `{"text":"aGVsbG8","html":"PHA-aGk8L3A-","references":"<a@x> <b@x>","mime_type":"text/plain","content":"x"}`,
+			want: `{"text":"hello","html":"<p>hi</p>","references":["<a@x>","<b@x>"]}`,
+		},
+		{
+			name: "bodies of other emails",
+			in:   `{"text":"aGVsbG8","html":"PHA-aGk8L3A-"}`,
+			want: `{"text":"aGVsbG8","html":"PHA-aGk8L3A-"}`,
+		},
+		{
+			name: "bodies that aren't encoded",
+			in:   `{"mime_type":"multipart/alternative","text":"Thanks","html":"aGVsbG8"}`,
+			want: `{"text":"Thanks","html":"aGVsbG8"}`,
+		},
+		{
+			name: "body",
+			in:   `{"body":"plain text","attachments":"[]"}`,
+			want: `{"text":"plain text"}`,
+		},
+		{
+			name: "version 2 shape",
+			in:   `{"from":{"email":"alice@example.com"},"date":"2006-01-02T22:04:05Z","text":"hello there","references":["<a@x>"]}`,
+			want: `{"from":{"email":"alice@example.com"},"date":"2006-01-02T22:04:05Z","text":"hello there","references":["<a@x>"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrate("Email", 1, 2, []byte(tt.in))
+			if err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+// assertJSON compares JSON documents regardless of key order.
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+package index
+
+import (
+	"encoding/base64"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterMigration("Email", 1, migrateEmailV1)
+
+	// Requests named the plugin instance they are for plugin_id
+	for _, kind := range []string{"ExtractRequest", "TransformRequest"} {
+		RegisterMigration(kind, 1, renameField("plugin_id", "instance_hash"))
+		RegisterRename(kind, "plugin_id", "instance_hash")
+	}
+}
+
+// renameField returns a migration renaming the field from to to.
+func renameField(from, to string) Migration {
+	return func(content map[string]interface{}) (map[string]interface{}, error) {
+		if value, ok := content[from]; ok {
+			if _, ok := content[to]; !ok {
+				content[to] = value
+			}
+			delete(content, from)
+		}
+		return content, nil
+	}
+}
+
+// migrateEmailV1 parses the raw headers stored by version 1 of Email and
+// decodes its bodies. Content stored before versions were recorded may already
+// have the version 2 shape, so values that are not strings are left alone.
+func migrateEmailV1(content map[string]interface{}) (map[string]interface{}, error) {
+	if from, ok := content["from"].(string); ok {
+		if addresses := emailAddresses(from); len(addresses) > 0 {
+			content["from"] = addresses[0]
+		} else {
+			delete(content, "from")
+		}
+	}
+
+	for _, key := range []string{"to", "cc", "bcc"} {
+		if value, ok := content[key].(string); ok {
+			if addresses := emailAddresses(value); len(addresses) > 0 {
+				content[key] = addresses
+			} else {
+				delete(content, key)
+			}
+		}
+	}
+
+	if date, ok := content["date"].(string); ok {
+		if _, err := time.Parse(time.RFC3339Nano, date); err != nil {
+			if t, err := mail.ParseDate(date); err == nil {
+				content["date"] = t.UTC().Format(time.RFC3339Nano)
+			} else {
+				delete(content, "date")
+			}
+		}
+	}
+
+	if references, ok := content["references"].(string); ok {
+		content["references"] = strings.Fields(references)
+	}
+
+	// The Gmail plugin of version 1 stored the base64url data of the message
+	// parts, along with the MIME type of the message. Bodies of other emails
+	// are left alone.
+	if rawParts(content) {
+		for _, key := range []string{"text", "html"} {
+			data, ok := content[key].(string)
+			if !ok {
+				continue
+			}
+			b, ok := decodeBody(data)
+			if ok && (key != "html" || strings.Contains(b, "<")) {
+				content[key] = b
+			}
+		}
+	}
+
+	if body, ok := content["body"].(string); ok {
+		if text, _ := content["text"].(string); text == "" {
+			content["text"] = body
+		}
+	}
+
+	delete(content, "body")
+	delete(content, "content")
+	delete(content, "mime_type")
+	delete(content, "content_type")
+	if _, ok := content["attachments"].(string); ok {
+		delete(content, "attachments")
+	}
+
+	return content, nil
+}
+
+// emailAddresses parses an address list header into EmailAddress values. A
+// malformed header is kept as the name of a single address.
+func emailAddresses(value string) []interface{} {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	list, err := mail.ParseAddressList(value)
+	if err != nil {
+		return []interface{}{map[string]interface{}{"name": value}}
+	}
+
+	addresses := make([]interface{}, len(list))
+	for n, a := range list {
+		address := map[string]interface{}{"email": a.Address}
+		if a.Name != "" {
+			address["name"] = a.Name
+		}
+		addresses[n] = address
+	}
+	return addresses
+}
+
+// rawParts reports whether an Email of version 1 was stored with the raw
+// message parts.
+func rawParts(content map[string]interface{}) bool {
+	for _, key := range []string{"mime_type", "content_type"} {
+		if value, _ := content[key].(string); value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBody decodes base64url data, padded or not. Text that isn't encoded,
+// or doesn't decode to text, is reported as not ok.
+func decodeBody(data string) (string, bool) {
+	if data == "" || strings.ContainsAny(data, " \t\r\n") {
+		return "", false
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(data, "="))
+	if err != nil || !utf8.Valid(b) {
+		return "", false
+	}
+
+	for _, r := range string(b) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return "", false
+		}
+	}
+	return string(b), true
+}
@@ -0,0 +1,163 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Postgres stores the index in PostgreSQL, so that several hosts can share it.
+// Metadata fields get their own columns like in SQLite, and are also stored in
+// a JSONB column with a GIN index and a tsvector for full text search.
+type Postgres struct{}
+
+var _ Backend = Postgres{}
+
+// postgresSchema is executed in order by CreateTables
+var postgresSchema = []string{
+	`CREATE TABLE IF NOT EXISTS index_data (
+		_id BIGSERIAL PRIMARY KEY,
+		schema_kind TEXT NOT NULL,
+		permanode_hash TEXT,
+		timestamp BIGINT,
+		content_hash TEXT,
+		delete_hash TEXT,
+		schema_version BIGINT,
+		_metadata JSONB NOT NULL DEFAULT '{}',
+		_search TSVECTOR GENERATED ALWAYS AS (jsonb_to_tsvector('simple', _metadata, '["string"]')) STORED
+	)`,
+	`CREATE INDEX IF NOT EXISTS index_data_content_hash ON index_data (content_hash)`,
+	`CREATE INDEX IF NOT EXISTS index_data_permanode_hash ON index_data (permanode_hash)`,
+	`CREATE INDEX IF NOT EXISTS index_data_metadata ON index_data USING GIN (_metadata jsonb_path_ops)`,
+	`CREATE INDEX IF NOT EXISTS index_data_search ON index_data USING GIN (_search)`,
+	createEdgesSQL,
+	`CREATE INDEX IF NOT EXISTS edges_to_hash ON edges (to_hash)`,
+	`CREATE TABLE IF NOT EXISTS changes (
+		seq BIGSERIAL PRIMARY KEY,
+		type TEXT NOT NULL,
+		claim_hash TEXT NOT NULL UNIQUE,
+		schema_kind TEXT,
+		content_hash TEXT,
+		permanode_hash TEXT,
+		delete_hash TEXT,
+		timestamp BIGINT
+	)`,
+}
+
+// changesLock is the advisory lock serializing the inserts of changes
+const changesLock = 0x64617461712d6368
+
+// undefinedColumn is the SQLSTATE for references to a missing column
+const undefinedColumn = "42703"
+
+func (Postgres) Placeholder() sq.PlaceholderFormat {
+	return sq.Dollar
+}
+
+func (Postgres) CreateTables(ctx context.Context, db *sql.DB) error {
+	for _, stmt := range postgresSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create index tables: %w", err)
+		}
+	}
+	return nil
+}
+
+func (Postgres) Columns(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'index_data'
+		ORDER BY ordinal_position`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table info: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, name)
+	}
+
+	return columns, rows.Err()
+}
+
+func (Postgres) AddColumn(ctx context.Context, db *sql.DB, name string, value interface{}) error {
+	colType := columnType(value)
+	switch colType {
+	case "INTEGER":
+		colType = "BIGINT"
+	case "REAL":
+		colType = "DOUBLE PRECISION"
+	}
+
+	// Another host may have added the column since the columns were read
+	alterSQL := "ALTER TABLE index_data ADD COLUMN IF NOT EXISTS " + QuoteIdent(name) + " " + colType
+	if _, err := db.ExecContext(ctx, alterSQL); err != nil {
+		return fmt.Errorf("failed to add column: %w (%s)", err, alterSQL)
+	}
+	return nil
+}
+
+func (Postgres) UndefinedColumn(err error) bool {
+	var state interface{ SQLState() string }
+	return errors.As(err, &state) && state.SQLState() == undefinedColumn
+}
+
+func (Postgres) InsertIgnore(insert sq.InsertBuilder) sq.InsertBuilder {
+	return insert.Suffix("ON CONFLICT DO NOTHING")
+}
+
+// InsertChange holds a lock until the change is committed. The seq is assigned
+// when the row is inserted, so without it a change may commit after one with a
+// greater seq was read.
+func (Postgres) InsertChange(ctx context.Context, db *sql.DB, insert sq.InsertBuilder) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", changesLock); err != nil {
+		return fmt.Errorf("failed to lock changes: %w", err)
+	}
+	if _, err := insert.RunWith(tx).ExecContext(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (Postgres) RowID() string {
+	return "_id"
+}
+
+// Like casts the column to text so that it also matches numeric columns.
+func (Postgres) Like(column, pattern string) sq.Sqlizer {
+	return sq.Expr("CAST("+column+` AS TEXT) ILIKE ? ESCAPE '\'`, pattern)
+}
+
+// Search uses the tsvector of the metadata rather than the individual columns.
+func (Postgres) Search(text string, _ []string) sq.Sqlizer {
+	return sq.Expr("_search @@ plainto_tsquery('simple', ?)", text)
+}
+
+func (Postgres) Extra(metadata map[string]interface{}) (map[string]interface{}, error) {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	return map[string]interface{}{"_metadata": string(b)}, nil
+}
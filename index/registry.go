@@ -0,0 +1,114 @@
+package index
+
+import (
+	"fmt"
+	"sync"
+
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// registry maps schema kinds to constructors for the Go types stored under them
+var registry = struct {
+	sync.RWMutex
+	kinds map[string]func() Indexable
+
+	// descriptors of the kinds registered with RegisterMessage
+	descriptors map[string]protoreflect.MessageDescriptor
+}{
+	kinds:       make(map[string]func() Indexable),
+	descriptors: make(map[string]protoreflect.MessageDescriptor),
+}
+
+func init() {
+	Register("PluginInstance", func() Indexable { return &schema.PluginInstance{} })
+	Register("SyncState", func() Indexable { return &schema.SyncState{} })
+	Register("PluginBlob", func() Indexable { return &schema.PluginBlob{} })
+
+	rpc.RegisterSchemaKinds(RegisterMessage)
+	rpc.RegisterDataqKinds(RegisterMessage)
+	rpc.RegisterOauth2Kinds(RegisterMessage)
+}
+
+// Register makes a schema kind available to UnmarshalContent. newFn returns an
+// empty value to unmarshal content into. Generated protobuf messages are found
+// through the protobuf registry if they haven't been registered with
+// RegisterMessage, but a registered kind takes precedence.
+func Register(kind string, newFn func() Indexable) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.kinds[kind] = newFn
+}
+
+// RegisterMessage registers a protobuf message as a schema kind along with its
+// descriptor. It is passed to the Register*Kinds functions generated by
+// protoc-gen-dataq-index.
+func RegisterMessage(kind string, desc protoreflect.MessageDescriptor, newFn func() protoreflect.ProtoMessage) {
+	Register(kind, func() Indexable {
+		return newFn().(Indexable)
+	})
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.descriptors[kind] = desc
+}
+
+// Descriptor returns the descriptor of a kind registered with RegisterMessage.
+func Descriptor(kind string) (protoreflect.MessageDescriptor, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	desc, ok := registry.descriptors[kind]
+	return desc, ok
+}
+
+// New returns an empty value of the Go type stored under a schema kind.
+func New(kind string) (Indexable, error) {
+	registry.RLock()
+	newFn, ok := registry.kinds[kind]
+	registry.RUnlock()
+	if ok {
+		return newFn(), nil
+	}
+
+	mt, err := findMessage(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	newFn = func() Indexable {
+		return mt.New().Interface().(Indexable)
+	}
+
+	Register(kind, newFn)
+	return newFn(), nil
+}
+
+// findMessage finds the protobuf message named kind that implements Indexable.
+func findMessage(kind string) (protoreflect.MessageType, error) {
+	var found []protoreflect.MessageType
+	protoregistry.GlobalTypes.RangeMessages(func(mt protoreflect.MessageType) bool {
+		if string(mt.Descriptor().Name()) != kind {
+			return true
+		}
+
+		if _, ok := mt.Zero().Interface().(Indexable); ok {
+			found = append(found, mt)
+		}
+		return true
+	})
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unknown schema kind: %s", kind)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("ambiguous schema kind %s: found %s and %s, register one explicitly",
+			kind, found[0].Descriptor().FullName(), found[1].Descriptor().FullName())
+	}
+}
@@ -0,0 +1,125 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// SQLite is the default backend, storing each metadata field in its own column.
+type SQLite struct{}
+
+var _ Backend = SQLite{}
+
+func (SQLite) Placeholder() sq.PlaceholderFormat {
+	return sq.Question
+}
+
+func (SQLite) CreateTables(ctx context.Context, db *sql.DB) error {
+	// it is possible to index and store content that is not part of a permanode.
+	// This content cannot be edited
+	createTableSQL := `CREATE TABLE IF NOT EXISTS index_data (
+		schema_kind TEXT NOT NULL,
+		permanode_hash TEXT,
+		timestamp INTEGER,
+		content_hash TEXT,
+		delete_hash TEXT,
+		schema_version INTEGER
+	)`
+
+	if _, err := db.ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("failed to create index table: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, createEdgesSQL); err != nil {
+		return fmt.Errorf("failed to create edges table: %w", err)
+	}
+
+	createChangesSQL := `CREATE TABLE IF NOT EXISTS changes (
+		seq INTEGER PRIMARY KEY AUTOINCREMENT,
+		type TEXT NOT NULL,
+		claim_hash TEXT NOT NULL UNIQUE,
+		schema_kind TEXT,
+		content_hash TEXT,
+		permanode_hash TEXT,
+		delete_hash TEXT,
+		timestamp INTEGER
+	)`
+
+	if _, err := db.ExecContext(ctx, createChangesSQL); err != nil {
+		return fmt.Errorf("failed to create changes table: %w", err)
+	}
+
+	return nil
+}
+
+func (SQLite) Columns(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "PRAGMA table_info(index_data)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table info: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var cid int
+		var name, type_ string
+		var notnull, pk int
+		var dflt_value interface{}
+
+		if err := rows.Scan(&cid, &name, &type_, &notnull, &dflt_value, &pk); err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, name)
+	}
+
+	return columns, rows.Err()
+}
+
+func (SQLite) AddColumn(ctx context.Context, db *sql.DB, name string, value interface{}) error {
+	alterSQL := "ALTER TABLE index_data ADD COLUMN " + QuoteIdent(name) + " " + columnType(value)
+	if _, err := db.ExecContext(ctx, alterSQL); err != nil {
+		return fmt.Errorf("failed to add column: %w (%s)", err, alterSQL)
+	}
+	return nil
+}
+
+func (SQLite) UndefinedColumn(err error) bool {
+	return strings.Contains(err.Error(), "no such column")
+}
+
+func (SQLite) InsertIgnore(insert sq.InsertBuilder) sq.InsertBuilder {
+	return insert.Options("OR IGNORE")
+}
+
+// InsertChange inserts the change as is, SQLite has a single writer so rows
+// are committed in the order of their seq.
+func (SQLite) InsertChange(ctx context.Context, db *sql.DB, insert sq.InsertBuilder) error {
+	_, err := insert.RunWith(db).ExecContext(ctx)
+	return err
+}
+
+func (SQLite) RowID() string {
+	return "rowid"
+}
+
+// Like uses LIKE, which is case insensitive for ASCII in SQLite.
+func (SQLite) Like(column, pattern string) sq.Sqlizer {
+	return sq.Expr(column+` LIKE ? ESCAPE '\'`, pattern)
+}
+
+func (s SQLite) Search(text string, columns []string) sq.Sqlizer {
+	var or sq.Or
+	for _, c := range columns {
+		or = append(or, s.Like(c, "%"+EscapeLike(text)+"%"))
+	}
+	return or
+}
+
+func (SQLite) Extra(map[string]interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
@@ -3,7 +3,11 @@ package repo
 import (
 	"context"
 	"fmt"
+	"maps"
+	"sync"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"go.quinn.io/dataq/index"
 	"go.quinn.io/dataq/rpc"
 	"go.quinn.io/dataq/schema"
@@ -12,6 +16,9 @@ import (
 
 type Repo struct {
 	index *index.Index
+
+	// syncMu orders sync state updates, so none is lost
+	syncMu sync.Mutex
 }
 
 func NewRepo(idx *index.Index) *Repo {
@@ -52,6 +59,7 @@ func (r *Repo) StoreExtractRequest(ctx context.Context, fullReq *rpc.ExtractRequ
 	// need to copy value to avoid modifying the original
 	req := proto.Clone(fullReq).(*rpc.ExtractRequest)
 	req.Oauth = nil
+	req.State = nil
 	hash, err := r.index.Store(ctx, req)
 	if err != nil {
 		return "", err
@@ -74,3 +82,89 @@ func (r *Repo) GetContent(ctx context.Context, hash string, result index.Indexab
 	sel := r.index.Q.Where("content_hash = ?", hash)
 	return r.index.Get(ctx, result, sel)
 }
+
+// SyncState returns the sync state of a plugin instance, empty if it was never
+// updated.
+func (r *Repo) SyncState(ctx context.Context, instance string) (*schema.SyncState, error) {
+	state, _, err := r.syncState(ctx, instance)
+	return state, err
+}
+
+// syncState returns the sync state of an instance and its permanode, which is
+// empty if the state was never updated.
+func (r *Repo) syncState(ctx context.Context, instance string) (*schema.SyncState, string, error) {
+	claims, err := r.index.Query(ctx, r.index.Q.
+		Where(sq.Eq{"schema_kind": "SyncState"}).
+		Where(sq.Eq{"plugin_id": instance}).
+		Where(sq.NotEq{"permanode_hash": ""}).
+		OrderBy("updated_at DESC").
+		Limit(1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query sync state: %w", err)
+	}
+	if len(claims) == 0 {
+		return &schema.SyncState{PluginID: instance}, "", nil
+	}
+
+	state := new(schema.SyncState)
+	if err := r.GetContent(ctx, claims[0].ContentHash, state); err != nil {
+		return nil, "", fmt.Errorf("failed to get sync state: %w", err)
+	}
+
+	return state, claims[0].PermanodeHash, nil
+}
+
+// UpdateSyncState sets keys of the sync state of a plugin instance, and
+// removes the keys with an empty value. A version is only stored if the state
+// changed.
+func (r *Repo) UpdateSyncState(ctx context.Context, instance string, updates map[string][]byte) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	r.syncMu.Lock()
+	defer r.syncMu.Unlock()
+
+	prev, permanodeHash, err := r.syncState(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	values := maps.Clone(prev.Values)
+	if values == nil {
+		values = make(map[string][]byte)
+	}
+	for key, value := range updates {
+		if len(value) == 0 {
+			delete(values, key)
+		} else {
+			values[key] = value
+		}
+	}
+	if maps.EqualFunc(values, prev.Values, func(a, b []byte) bool { return string(a) == string(b) }) {
+		return nil
+	}
+
+	// Versions are ordered by millisecond, an update in the same millisecond as
+	// the previous one must still sort after it
+	now := time.Now()
+	if now.UnixMilli() <= prev.UpdatedAt.UnixMilli() {
+		now = time.UnixMilli(prev.UpdatedAt.UnixMilli() + 1)
+	}
+
+	state := &schema.SyncState{
+		PluginID:  instance,
+		Values:    values,
+		UpdatedAt: now,
+	}
+	if permanodeHash == "" {
+		_, err = r.index.CreatePermanode(ctx, state)
+	} else {
+		_, err = r.index.UpdatePermanode(ctx, permanodeHash, state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store sync state: %w", err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 func RegisterRoutes(e *echo.Echo) {
 	e.GET("/blob/:hash", BlobHashGET)
 	e.GET("/content", ContentGET)
+	e.GET("/graph/:hash", GraphHashGET)
 	e.GET("/", IndexGET)
 	e.GET("/plugin/:id/edit", PluginIdEditGET)
 	e.POST("/plugin/:id/edit", PluginIdEditPOST)
@@ -22,10 +23,13 @@ func RegisterRoutes(e *echo.Echo) {
 	e.POST("/plugin/:id/transform/:hash", PluginIdTransformHashPOST)
 	e.GET("/plugin/install", PluginInstallGET)
 	e.POST("/plugin/install", PluginInstallPOST)
+	e.GET("/plugin/status", PluginStatusGET)
+	e.GET("/schema/:kind/:hash", SchemaKindHashGET)
 	e.GET("/schema/extract-request/:hash", SchemaExtractRequestHashGET)
 	e.POST("/schema/extract-request/:hash", SchemaExtractRequestHashPOST)
 	e.GET("/schema/transform-request/:hash", SchemaTransformRequestHashGET)
 	e.POST("/schema/transform-request/:hash", SchemaTransformRequestHashPOST)
+	e.GET("/search", SearchGET)
 }
 
 // BlobHashGET handles GET requests to /blob/:hash
@@ -46,6 +50,15 @@ func ContentGET(c echo.Context) error {
 	return pages.Content(result).Render(c.Request().Context(), c.Response().Writer)
 }
 
+// GraphHashGET handles GET requests to /graph/:hash
+func GraphHashGET(c echo.Context) error {
+	result, err := pages.GraphHashGET(c, c.Param("hash"))
+	if err != nil {
+		return err
+	}
+	return pages.GraphHash(result).Render(c.Request().Context(), c.Response().Writer)
+}
+
 // IndexGET handles GET requests to /
 func IndexGET(c echo.Context) error {
 	result, err := pages.IndexGET(c)
@@ -134,6 +147,24 @@ func PluginInstallPOST(c echo.Context) error {
 	return pages.PluginInstallPOST(c)
 }
 
+// PluginStatusGET handles GET requests to /plugin/status
+func PluginStatusGET(c echo.Context) error {
+	result, err := pages.PluginStatusGET(c)
+	if err != nil {
+		return err
+	}
+	return pages.PluginStatus(result).Render(c.Request().Context(), c.Response().Writer)
+}
+
+// SchemaKindHashGET handles GET requests to /schema/:kind/:hash
+func SchemaKindHashGET(c echo.Context) error {
+	result, err := pages.SchemaKindHashGET(c, c.Param("kind"), c.Param("hash"))
+	if err != nil {
+		return err
+	}
+	return pages.SchemaKindHash(result).Render(c.Request().Context(), c.Response().Writer)
+}
+
 // SchemaExtractRequestHashGET handles GET requests to /schema/extract-request/:hash
 func SchemaExtractRequestHashGET(c echo.Context) error {
 	result, err := pages.SchemaExtractRequestHashGET(c, c.Param("hash"))
@@ -161,3 +192,12 @@ func SchemaTransformRequestHashGET(c echo.Context) error {
 func SchemaTransformRequestHashPOST(c echo.Context) error {
 	return pages.SchemaTransformRequestHashPOST(c, c.Param("hash"))
 }
+
+// SearchGET handles GET requests to /search
+func SearchGET(c echo.Context) error {
+	result, err := pages.SearchGET(c)
+	if err != nil {
+		return err
+	}
+	return pages.Search(result).Render(c.Request().Context(), c.Response().Writer)
+}
@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.quinn.io/dataq/internal/middleware"
+)
+
+// ApiChanges streams index changes as server-sent events. Clients resume after
+// the Last-Event-ID header or the since parameter, otherwise only new changes
+// are sent.
+func ApiChanges(c echo.Context) error {
+	b := middleware.GetBoot(c)
+	ctx := c.Request().Context()
+
+	since := c.Request().Header.Get("Last-Event-ID")
+	if since == "" {
+		since = c.QueryParam("since")
+	}
+
+	var seq int64
+	var err error
+	if since == "" {
+		seq, err = b.Index.LatestSeq(ctx)
+		if err != nil {
+			return err
+		}
+	} else if seq, err = strconv.ParseInt(since, 10, 64); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid since: "+since)
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	for change, err := range b.Index.Subscribe(ctx, seq) {
+		if err != nil {
+			// The response has started, so the error can only be reported in the stream
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+			w.Flush()
+			return nil
+		}
+
+		data, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: change\ndata: %s\n\n", change.Seq, data); err != nil {
+			return nil
+		}
+		w.Flush()
+	}
+
+	return nil
+}
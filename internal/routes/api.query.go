@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.quinn.io/dataq/internal/middleware"
+	"go.quinn.io/dataq/query"
+)
+
+func ApiQuery(c echo.Context) error {
+	b := middleware.GetBoot(c)
+
+	result, err := query.Run(c.Request().Context(), b.Index, c.QueryParam("q"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -27,6 +27,7 @@ func PluginOauthComplete(c echo.Context) error {
 
 	// Save the token
 	plugin.Oauth.Token = schema.NewRPCOauthToken(token)
+	plugin.OauthRevoked = false
 	if _, err := b.Index.UpdatePermanode(c.Request().Context(), hash, &plugin); err != nil {
 		return fmt.Errorf("failed to update plugin: %w", err)
 	}
@@ -8,5 +8,7 @@ import (
 func addRoutes(e *echo.Echo) {
 	e.GET("/plugin/:hash/oauth/complete", routes.PluginOauthComplete).Name = "plugin.oauth.complete"
 	e.GET("/content/:hash", routes.Content).Name = "content"
+	e.GET("/api/query", routes.ApiQuery).Name = "api.query"
+	e.GET("/api/changes", routes.ApiChanges).Name = "api.changes"
 	/* insert new routes here */
 }
@@ -56,7 +56,9 @@ func PluginIdOauthBeginPOST(c echo.Context, id string) error {
 	}
 
 	oauthConfig := schema.NewOauthConfig(plugin.Oauth)
-	authURL := oauthConfig.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	// Providers only issue a new refresh token when consent is asked again,
+	// e.g. after the previous one was revoked
+	authURL := oauthConfig.AuthCodeURL("state-token", oauth2.AccessTypeOffline, oauth2.ApprovalForce)
 
 	return c.Redirect(http.StatusFound, authURL)
 }
@@ -101,7 +103,7 @@ func PluginIdOauthBegin(data PluginIdOauthBeginData) templ.Component {
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(data.plugin.Label)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `pages/plugin.[id].oauth.begin.templ`, Line: 60, Col: 78}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `pages/plugin.[id].oauth.begin.templ`, Line: 62, Col: 78}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
@@ -114,7 +116,7 @@ func PluginIdOauthBegin(data PluginIdOauthBeginData) templ.Component {
 			var templ_7745c5c3_Var4 string
 			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(data.redirectURL)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `pages/plugin.[id].oauth.begin.templ`, Line: 62, Col: 43}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `pages/plugin.[id].oauth.begin.templ`, Line: 64, Col: 43}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
@@ -65,15 +65,15 @@ func PluginIdSendReqtypeKindPOST(c echo.Context, id, reqtype, kind string) error
 		config[field.Key] = c.FormValue(field.Key)
 	}
 
-	client, ok := b.Plugins.Clients[data.plugin.PluginID]
-	if !ok {
-		return fmt.Errorf("plugin not found: %s", data.plugin.PluginID)
+	client, err := b.Plugins.GetClient(data.plugin.PluginID)
+	if err != nil {
+		return err
 	}
 
 	_, err = client.Extract(c.Request().Context(), &data.plugin, &rpc.ExtractRequest{
-		PluginId: id,
-		Kind:     kind,
-		Metadata: config,
+		InstanceHash: id,
+		Kind:         kind,
+		Metadata:     config,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to send extract request: %w", err)
@@ -58,16 +58,11 @@ func PluginIdTransformHashPOST(c echo.Context, id, hash string) error {
 	ctx := c.Request().Context()
 	b := middleware.GetBoot(c)
 
-	in, err := b.Repo.GetPluginInstance(ctx, id)
+	plugin, _, err := b.Plugins.Instance(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get plugin instance: %w", err)
 	}
 
-	plugin, ok := b.Plugins.Clients[in.PluginID]
-	if !ok {
-		return fmt.Errorf("plugin not found: %s", id)
-	}
-
 	var req rpc.TransformRequest
 	if err := b.Repo.GetContent(ctx, hash, &req); err != nil {
 		return fmt.Errorf("error getting request from index: %w", err)
@@ -14,6 +14,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"go.quinn.io/dataq/boot"
 	"go.quinn.io/dataq/htmx"
+	"go.quinn.io/dataq/index"
 	"go.quinn.io/dataq/internal/middleware"
 	"go.quinn.io/dataq/rpc"
 	"go.quinn.io/dataq/ui"
@@ -26,7 +27,8 @@ type SchemaExtractRequestHashData struct {
 		res  *rpc.ExtractResponse
 		data []byte
 	}
-	hash string
+	hash    string
+	lineage []index.LineageStep
 }
 
 func SchemaExtractRequestHashGET(c echo.Context, hash string) (SchemaExtractRequestHashData, error) {
@@ -66,6 +68,10 @@ func SchemaExtractRequestHashGET(c echo.Context, hash string) (SchemaExtractRequ
 		}{res: &res, data: d})
 	}
 
+	if data.lineage, err = b.Index.Lineage(c.Request().Context(), hash); err != nil {
+		return data, fmt.Errorf("failed to get lineage: %w", err)
+	}
+
 	data.req = &req
 	data.hash = hash
 	return data, nil
@@ -80,20 +86,11 @@ func SchemaExtractRequestHashPOST(c echo.Context, hash string) error {
 		return fmt.Errorf("error getting request from index: %w", err)
 	}
 
-	plugin, err := b.Repo.GetPluginInstance(c.Request().Context(), req.PluginId)
+	client, plugin, err := b.Plugins.Instance(c.Request().Context(), req.InstanceHash)
 	if err != nil {
 		return fmt.Errorf("error getting plugin instance: %w", err)
 	}
 
-	client, ok := b.Plugins.Clients[plugin.PluginID]
-	if !ok {
-		var keys []string
-		for k := range b.Plugins.Clients {
-			keys = append(keys, k)
-		}
-		return fmt.Errorf("plugin not found: %s. Plugin IDs are: %v", plugin.PluginID, keys)
-	}
-
 	if _, err := client.Extract(c.Request().Context(), plugin, &req); err != nil {
 		return fmt.Errorf("error extracting: %w", err)
 	}
@@ -168,7 +165,15 @@ func SchemaExtractRequestHash(data SchemaExtractRequestHashData) templ.Component
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</ul><hr><div class=\"font-bold\">Actions</div><ul class=\"list-disc list-inside\"><li class=\"list-item\"><button hx-post class=\"underline\">Send</button></li></ul></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</ul><hr>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = ui.Provenance(data.lineage).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<hr><div class=\"font-bold\">Actions</div><ul class=\"list-disc list-inside\"><li class=\"list-item\"><button hx-post class=\"underline\">Send</button></li></ul></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
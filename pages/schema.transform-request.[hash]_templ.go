@@ -14,6 +14,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"go.quinn.io/dataq/boot"
 	"go.quinn.io/dataq/htmx"
+	"go.quinn.io/dataq/index"
 	"go.quinn.io/dataq/internal/middleware"
 	"go.quinn.io/dataq/rpc"
 	"go.quinn.io/dataq/ui"
@@ -24,7 +25,8 @@ type SchemaTransformRequestHashData struct {
 	res []struct {
 		res *rpc.TransformResponse
 	}
-	hash string
+	hash    string
+	lineage []index.LineageStep
 }
 
 func SchemaTransformRequestHashGET(c echo.Context, hash string) (SchemaTransformRequestHashData, error) {
@@ -55,6 +57,10 @@ func SchemaTransformRequestHashGET(c echo.Context, hash string) (SchemaTransform
 		}{res: &res})
 	}
 
+	if data.lineage, err = b.Index.Lineage(c.Request().Context(), hash); err != nil {
+		return data, fmt.Errorf("failed to get lineage: %w", err)
+	}
+
 	data.req = &req
 	data.hash = hash
 	return data, nil
@@ -69,20 +75,11 @@ func SchemaTransformRequestHashPOST(c echo.Context, hash string) error {
 		return fmt.Errorf("error getting request from index: %w", err)
 	}
 
-	plugin, err := b.Repo.GetPluginInstance(c.Request().Context(), req.PluginId)
+	client, _, err := b.Plugins.Instance(c.Request().Context(), req.InstanceHash)
 	if err != nil {
 		return fmt.Errorf("error getting plugin instance: %w", err)
 	}
 
-	client, ok := b.Plugins.Clients[plugin.PluginID]
-	if !ok {
-		var keys []string
-		for k := range b.Plugins.Clients {
-			keys = append(keys, k)
-		}
-		return fmt.Errorf("plugin not found: %s. Plugin IDs are: %v", plugin.PluginID, keys)
-	}
-
 	if _, err := client.Transform(c.Request().Context(), &req); err != nil {
 		return fmt.Errorf("error transforming: %w", err)
 	}
@@ -149,7 +146,15 @@ func SchemaTransformRequestHash(data SchemaTransformRequestHashData) templ.Compo
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</ul><hr><div class=\"font-bold\">Actions</div><ul class=\"list-disc list-inside\"><li class=\"list-item\"><button hx-post class=\"underline\">Send</button></li></ul></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</ul><hr>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = ui.Provenance(data.lineage).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<hr><div class=\"font-bold\">Actions</div><ul class=\"list-disc list-inside\"><li class=\"list-item\"><button hx-post class=\"underline\">Send</button></li></ul></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -0,0 +1,191 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.quinn.io/dataq/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Extract declares an extract kind.
+type Extract struct {
+	Kind        string
+	Label       string
+	Description string
+
+	// Freshness says when the host reuses responses, nil calls the plugin
+	// every time
+	Freshness *rpc.Freshness
+}
+
+// ExtractRequest is an extract request sent by the host.
+type ExtractRequest struct {
+	*rpc.ExtractRequest
+}
+
+// TransformRequest is a transform request sent by the host, with the extracted
+// content attached.
+type TransformRequest struct {
+	*rpc.TransformRequest
+}
+
+// ExtractFunc handles an extract request. params is decoded from the request
+// metadata.
+type ExtractFunc[T any] func(ctx context.Context, req *ExtractRequest, params T) (*rpc.ExtractResponse, error)
+
+// TransformFunc handles a transform request. content is the extracted content,
+// decoded as JSON unless T is []byte.
+type TransformFunc[T any] func(ctx context.Context, req *TransformRequest, content T) (*rpc.TransformResponse, error)
+
+// ExtractStreamFunc handles an extract request of a streamed kind, calling send
+// for every item. params is decoded from the request metadata.
+type ExtractStreamFunc[T any] func(ctx context.Context, req *ExtractRequest, params T, send func(*rpc.ExtractResponse) error) error
+
+type extractHandler func(ctx context.Context, req *ExtractRequest) (*rpc.ExtractResponse, error)
+
+type extractStreamHandler func(ctx context.Context, req *ExtractRequest, send func(*rpc.ExtractResponse) error) error
+
+type transformHandler func(ctx context.Context, req *TransformRequest) (*rpc.TransformResponse, error)
+
+// HandleExtract registers h for extracts of kind e.Kind. T is a struct whose
+// string fields are the configs of the extract, tagged with their metadata key
+// and label:
+//
+//	type getMessage struct {
+//		MessageID string `config:"message_id" label:"Message ID"`
+//	}
+//
+// Configs are required unless tagged `config:"key,optional"`. It panics if T
+// is not such a struct or the kind is already registered.
+func HandleExtract[T any](p *Plugin, e Extract, h ExtractFunc[T]) {
+	decode := p.declareExtract(e, false, reflect.TypeFor[T]())
+	p.extractHandlers[e.Kind] = func(ctx context.Context, req *ExtractRequest) (*rpc.ExtractResponse, error) {
+		var params T
+		if err := decode(req, &params); err != nil {
+			return nil, err
+		}
+		return h(ctx, req, params)
+	}
+}
+
+// HandleExtractStream registers h for extracts of kind e.Kind, which produce
+// many items. The host stores every item h sends before it receives the next
+// one, so h is slowed down to the pace the host can keep. T is decoded like for
+// HandleExtract.
+func HandleExtractStream[T any](p *Plugin, e Extract, h ExtractStreamFunc[T]) {
+	decode := p.declareExtract(e, true, reflect.TypeFor[T]())
+	p.extractStreamHandlers[e.Kind] = func(ctx context.Context, req *ExtractRequest, send func(*rpc.ExtractResponse) error) error {
+		var params T
+		if err := decode(req, &params); err != nil {
+			return err
+		}
+		return h(ctx, req, params, send)
+	}
+}
+
+// declareExtract declares an extract kind whose params are of type t, and
+// returns the function decoding them from a request into a *t.
+func (p *Plugin) declareExtract(e Extract, stream bool, t reflect.Type) func(req *ExtractRequest, params any) error {
+	_, registered := p.extractHandlers[e.Kind]
+	if _, ok := p.extractStreamHandlers[e.Kind]; ok || registered {
+		panic(fmt.Sprintf("plugin: extract %s registered twice", e.Kind))
+	}
+
+	fields, err := paramFields(t)
+	if err != nil {
+		panic(fmt.Sprintf("plugin: extract %s: %v", e.Kind, err))
+	}
+
+	extract := &rpc.InstallResponse_Extract{
+		Kind:        e.Kind,
+		Label:       e.Label,
+		Description: e.Description,
+		Freshness:   e.Freshness,
+		Stream:      stream,
+	}
+	for _, f := range fields {
+		extract.Configs = append(extract.Configs, &rpc.PluginConfig{Key: f.key, Label: f.label})
+	}
+	p.extracts = append(p.extracts, extract)
+
+	return func(req *ExtractRequest, params any) error {
+		v := reflect.ValueOf(params).Elem()
+		for _, f := range fields {
+			value := req.Metadata[f.key]
+			if value == "" && !f.optional {
+				return status.Errorf(codes.InvalidArgument, "%s request requires %s in metadata", req.Kind, f.key)
+			}
+			v.Field(f.index).SetString(value)
+		}
+		return nil
+	}
+}
+
+// HandleTransform registers h for transforms of kind. It panics if the kind is
+// already registered.
+func HandleTransform[T any](p *Plugin, kind string, freshness *rpc.Freshness, h TransformFunc[T]) {
+	if _, ok := p.transformHandlers[kind]; ok {
+		panic(fmt.Sprintf("plugin: transform %s registered twice", kind))
+	}
+
+	p.transforms = append(p.transforms, &rpc.InstallResponse_Transform{
+		Kind:      kind,
+		Freshness: freshness,
+	})
+
+	p.transformHandlers[kind] = func(ctx context.Context, req *TransformRequest) (*rpc.TransformResponse, error) {
+		var content T
+		if raw, ok := any(&content).(*[]byte); ok {
+			*raw = req.GetContent()
+		} else if err := json.Unmarshal(req.GetContent(), &content); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal %s content: %v", kind, err)
+		}
+
+		return h(ctx, req, content)
+	}
+}
+
+type paramField struct {
+	index    int
+	key      string
+	label    string
+	optional bool
+}
+
+// paramFields returns the config fields of an extract params struct.
+func paramFields(t reflect.Type) ([]paramField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("params must be a struct, got %s", t)
+	}
+
+	var fields []paramField
+	for n := 0; n < t.NumField(); n++ {
+		sf := t.Field(n)
+		tag, ok := sf.Tag.Lookup("config")
+		if !ok {
+			continue
+		}
+		if sf.Type.Kind() != reflect.String || !sf.IsExported() {
+			return nil, fmt.Errorf("config %s must be an exported string field", sf.Name)
+		}
+
+		key, opts, _ := strings.Cut(tag, ",")
+		label := sf.Tag.Get("label")
+		if label == "" {
+			label = key
+		}
+		fields = append(fields, paramField{
+			index:    n,
+			key:      key,
+			label:    label,
+			optional: opts == "optional",
+		})
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"context"
+	"sync"
+
+	"go.quinn.io/dataq/handshake"
+	"go.quinn.io/dataq/rpc"
+)
+
+// Host calls the host service on behalf of the plugin instance a request was
+// sent for. The host only gives access to the data of that instance.
+type Host struct {
+	client   rpc.DataQHostClient
+	instance string
+}
+
+// host is the connection to the host, made on first use and shared by every
+// request
+var host struct {
+	once   sync.Once
+	client rpc.DataQHostClient
+	err    error
+}
+
+// Host returns the host service for the instance the request was sent for.
+// It fails if the plugin was not started by a host.
+func (r *ExtractRequest) Host() (*Host, error) {
+	return newHost(r.InstanceHash)
+}
+
+// Host returns the host service for the instance the request was sent for.
+// It fails if the plugin was not started by a host.
+func (r *TransformRequest) Host() (*Host, error) {
+	return newHost(r.InstanceHash)
+}
+
+func newHost(instance string) (*Host, error) {
+	host.once.Do(func() {
+		conn, err := handshake.DialHost()
+		if err != nil {
+			host.err = err
+			return
+		}
+		host.client = rpc.NewDataQHostClient(conn)
+	})
+	if host.err != nil {
+		return nil, host.err
+	}
+	return &Host{client: host.client, instance: instance}, nil
+}
+
+// GetBlob returns a blob the instance extracted, stored with PutBlob or
+// manages as the content of a permanode.
+func (h *Host) GetBlob(ctx context.Context, hash string) ([]byte, error) {
+	res, err := h.client.GetBlob(ctx, &rpc.GetBlobRequest{InstanceHash: h.instance, Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	return res.Content, nil
+}
+
+// PutBlob stores content and returns its address.
+func (h *Host) PutBlob(ctx context.Context, content []byte) (string, error) {
+	res, err := h.client.PutBlob(ctx, &rpc.PutBlobRequest{InstanceHash: h.instance, Content: content})
+	if err != nil {
+		return "", err
+	}
+	return res.Hash, nil
+}
+
+// QueryPermanodes returns the latest content of the permanodes the instance
+// manages that match q, most recently updated first.
+func (h *Host) QueryPermanodes(ctx context.Context, q *rpc.QueryOwnPermanodesRequest) ([]*rpc.QueryOwnPermanodesResponse_Permanode, error) {
+	req := &rpc.QueryOwnPermanodesRequest{
+		InstanceHash: h.instance,
+		Kind:         q.GetKind(),
+		Key:          q.GetKey(),
+		Filters:      q.GetFilters(),
+		Limit:        q.GetLimit(),
+	}
+	res, err := h.client.QueryOwnPermanodes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Permanodes, nil
+}
+
+// State returns the value of a key of the instance's sync state and whether it
+// is set. Extract requests carry the whole state.
+func (h *Host) State(ctx context.Context, key string) ([]byte, bool, error) {
+	res, err := h.client.GetPluginState(ctx, &rpc.GetPluginStateRequest{InstanceHash: h.instance, Key: key})
+	if err != nil {
+		return nil, false, err
+	}
+	return res.Value, res.Found, nil
+}
+
+// SetState sets a key of the instance's sync state, an empty value removes it.
+func (h *Host) SetState(ctx context.Context, key string, value []byte) error {
+	_, err := h.client.SetPluginState(ctx, &rpc.SetPluginStateRequest{InstanceHash: h.instance, Key: key, Value: value})
+	return err
+}
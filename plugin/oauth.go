@@ -0,0 +1,29 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.quinn.io/dataq/rpc"
+	"go.quinn.io/dataq/schema"
+	"golang.org/x/oauth2"
+)
+
+// Client returns an HTTP client authorized with the OAuth token the host
+// attached to the request. The host refreshes the token before it sends the
+// request.
+func (r *ExtractRequest) Client(ctx context.Context) (*http.Client, error) {
+	return Client(ctx, r.Oauth)
+}
+
+// Client returns an HTTP client authorized with an OAuth token sent by the
+// host. The host keeps the refresh token, plugins only get a fresh access
+// token.
+func Client(ctx context.Context, oauth *rpc.OAuth2) (*http.Client, error) {
+	if oauth.GetToken() == nil {
+		return nil, fmt.Errorf("no OAuth2 token provided")
+	}
+
+	return oauth2.NewClient(ctx, oauth2.StaticTokenSource(schema.NewOauthToken(oauth))), nil
+}
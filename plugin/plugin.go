@@ -0,0 +1,241 @@
+// Package plugin is the SDK for writing dataq plugins. A plugin declares its
+// configs, OAuth provider and handlers once and the SDK generates Install,
+// dispatches requests by kind and serves the host:
+//
+//	p := plugin.New("example")
+//	p.OAuth(github.Endpoint, "repo")
+//	plugin.HandleExtract(p, plugin.Extract{Kind: "repos", Label: "Repositories"}, listRepos)
+//	plugin.HandleTransform(p, "repos", plugin.Never(), transformRepos)
+//	plugin.Run(p)
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.quinn.io/dataq/handshake"
+	"go.quinn.io/dataq/rpc"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Plugin serves the handlers registered on it as a DataQPlugin.
+type Plugin struct {
+	rpc.UnimplementedDataQPluginServer
+
+	id          string
+	configs     []*rpc.PluginConfig
+	oauth       *rpc.OAuth2
+	descriptors []protoreflect.FileDescriptor
+
+	extracts              []*rpc.InstallResponse_Extract
+	extractHandlers       map[string]extractHandler
+	extractStreamHandlers map[string]extractStreamHandler
+	transforms            []*rpc.InstallResponse_Transform
+	transformHandlers     map[string]transformHandler
+}
+
+// New returns a plugin with no handlers.
+func New(id string) *Plugin {
+	return &Plugin{
+		id:                    id,
+		extractHandlers:       make(map[string]extractHandler),
+		extractStreamHandlers: make(map[string]extractStreamHandler),
+		transformHandlers:     make(map[string]transformHandler),
+	}
+}
+
+// ID returns the id of the plugin.
+func (p *Plugin) ID() string {
+	return p.id
+}
+
+// Config declares a plugin config the user sets when installing the plugin.
+func (p *Plugin) Config(key, label string) {
+	p.configs = append(p.configs, &rpc.PluginConfig{Key: key, Label: label})
+}
+
+// OAuth declares the OAuth provider of the plugin. The host runs the
+// authorization flow with the client the user configures and attaches the
+// token to extract requests.
+func (p *Plugin) OAuth(endpoint oauth2.Endpoint, scopes ...string) {
+	p.oauth = &rpc.OAuth2{
+		Config: &rpc.OAuth2_Config{
+			Endpoint: &rpc.OAuth2_Endpoint{
+				AuthUrl:  endpoint.AuthURL,
+				TokenUrl: endpoint.TokenURL,
+			},
+			Scopes: scopes,
+		},
+	}
+}
+
+// Descriptors declares the files of the messages the plugin returns as Any
+// permanode payloads. Their dependencies are included.
+func (p *Plugin) Descriptors(files ...protoreflect.FileDescriptor) {
+	p.descriptors = append(p.descriptors, files...)
+}
+
+// Never is the freshness of responses that can always be reused.
+func Never() *rpc.Freshness {
+	return &rpc.Freshness{Policy: rpc.Freshness_NEVER}
+}
+
+// TTL is the freshness of responses that can be reused until they are older
+// than d.
+func TTL(d time.Duration) *rpc.Freshness {
+	return &rpc.Freshness{Policy: rpc.Freshness_TTL, Ttl: durationpb.New(d)}
+}
+
+// Install describes the plugin from what was declared on it.
+func (p *Plugin) Install(ctx context.Context, req *rpc.InstallRequest) (*rpc.InstallResponse, error) {
+	return &rpc.InstallResponse{
+		PluginId:    p.id,
+		Configs:     p.configs,
+		Oauth:       p.oauth,
+		Extracts:    p.extracts,
+		Transforms:  p.transforms,
+		Descriptors: descriptorSet(p.descriptors),
+	}, nil
+}
+
+// Extract runs the handler registered for the kind of req.
+func (p *Plugin) Extract(ctx context.Context, req *rpc.ExtractRequest) (*rpc.ExtractResponse, error) {
+	h, ok := p.extractHandlers[req.Kind]
+	if !ok {
+		if _, ok := p.extractStreamHandlers[req.Kind]; ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "extract kind %s is streamed, call ExtractStream", req.Kind)
+		}
+		return nil, status.Errorf(codes.Unimplemented, "unknown extract kind: %s", req.Kind)
+	}
+
+	start := time.Now()
+	res, err := h(ctx, &ExtractRequest{ExtractRequest: req})
+	if err != nil {
+		log.Printf("Extract %s failed after %s: %v", req.Kind, time.Since(start), err)
+		return nil, fmt.Errorf("failed to extract %s: %w", req.Kind, err)
+	}
+	log.Printf("Extract %s took %s", req.Kind, time.Since(start))
+
+	return res, nil
+}
+
+// ExtractStream runs the handler registered for the kind of req, sending every
+// item it produces. Kinds that aren't streamed send their single response.
+func (p *Plugin) ExtractStream(req *rpc.ExtractRequest, stream rpc.DataQPlugin_ExtractStreamServer) error {
+	ctx := stream.Context()
+	h, ok := p.extractStreamHandlers[req.Kind]
+	if !ok {
+		unary, ok := p.extractHandlers[req.Kind]
+		if !ok {
+			return status.Errorf(codes.Unimplemented, "unknown extract kind: %s", req.Kind)
+		}
+		h = func(ctx context.Context, req *ExtractRequest, send func(*rpc.ExtractResponse) error) error {
+			res, err := unary(ctx, req)
+			if err != nil {
+				return err
+			}
+			return send(res)
+		}
+	}
+
+	start := time.Now()
+	items := 0
+	err := h(ctx, &ExtractRequest{ExtractRequest: req}, func(res *rpc.ExtractResponse) error {
+		items++
+		return stream.Send(res)
+	})
+	if err != nil {
+		log.Printf("Extract %s failed after %s and %d items: %v", req.Kind, time.Since(start), items, err)
+		return fmt.Errorf("failed to extract %s: %w", req.Kind, err)
+	}
+	log.Printf("Extract %s took %s for %d items", req.Kind, time.Since(start), items)
+
+	return nil
+}
+
+// Transform runs the handler registered for the kind of req.
+func (p *Plugin) Transform(ctx context.Context, req *rpc.TransformRequest) (*rpc.TransformResponse, error) {
+	h, ok := p.transformHandlers[req.Kind]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown transform kind: %s", req.Kind)
+	}
+
+	start := time.Now()
+	res, err := h(ctx, &TransformRequest{TransformRequest: req})
+	if err != nil {
+		log.Printf("Transform %s failed after %s: %v", req.Kind, time.Since(start), err)
+		return nil, fmt.Errorf("failed to transform %s: %w", req.Kind, err)
+	}
+	log.Printf("Transform %s took %s", req.Kind, time.Since(start))
+
+	return res, nil
+}
+
+// Serve serves p to the host until it is stopped, or the host sends SIGTERM.
+func Serve(p *Plugin) error {
+	s := grpc.NewServer()
+	rpc.RegisterDataQPluginServer(s, p)
+	reflection.Register(s)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(sig)
+	go func() {
+		if _, ok := <-sig; ok {
+			s.GracefulStop()
+		}
+	}()
+
+	return handshake.Serve(s)
+}
+
+// Run serves p and exits when serving fails.
+func Run(p *Plugin) {
+	log.SetPrefix(p.id + ": ")
+
+	if err := Serve(p); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+	log.Println("Server stopped")
+}
+
+// descriptorSet returns files and their dependencies, dependencies first.
+func descriptorSet(files []protoreflect.FileDescriptor) *descriptorpb.FileDescriptorSet {
+	if len(files) == 0 {
+		return nil
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+
+		imports := fd.Imports()
+		for n := 0; n < imports.Len(); n++ {
+			add(imports.Get(n).FileDescriptor)
+		}
+		fds.File = append(fds.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, fd := range files {
+		add(fd)
+	}
+
+	return fds
+}
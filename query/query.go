@@ -0,0 +1,211 @@
+// Package query implements a small search language for the index, e.g.
+//
+//	kind:Email from:"alice@" date>2024-01-01 has:attachments sort:-date limit:20
+//
+// Queries are parsed into a Query and compiled into parameterized SQL against
+// index_data. Field names are checked against the columns of the index, values
+// are always passed as arguments. A message field such as from matches any of
+// the fields it is indexed as, from.email and from.name.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 1000
+)
+
+// Filter is a single field comparison, e.g. from:"alice@" or date>2024-01-01.
+type Filter struct {
+	Field  string
+	Op     string // one of ":", "=", "!=", ">", ">=", "<", "<="
+	Value  string
+	Negate bool
+}
+
+// Text is a free text term, e.g. alice or -"out of office".
+type Text struct {
+	Value  string
+	Negate bool
+}
+
+// Sort orders results by a field.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+type Query struct {
+	Filters []Filter
+
+	// Has lists fields that must be set
+	Has []string
+
+	// Text are free text terms, matched against every field
+	Text []Text
+
+	Sort   []Sort
+	Fields []string
+	Limit  int
+	Page   int
+}
+
+// operators are ordered so that two character operators match first
+var operators = []string{">=", "<=", "!=", ":", "=", ">", "<"}
+
+// aliases map query field names to index columns
+var aliases = map[string]string{
+	"kind":      "schema_kind",
+	"permanode": "permanode_hash",
+	"content":   "content_hash",
+}
+
+// Parse parses a query string.
+func Parse(s string) (*Query, error) {
+	terms, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{Limit: DefaultLimit, Page: 1}
+	for _, term := range terms {
+		negate := false
+		if strings.HasPrefix(term, "-") && len(term) > 1 {
+			negate = true
+			term = term[1:]
+		}
+
+		field, op, value := splitTerm(term)
+		if op == "" {
+			q.Text = append(q.Text, Text{Value: unquote(term), Negate: negate})
+			continue
+		}
+
+		field = strings.ToLower(field)
+		value = unquote(value)
+
+		switch {
+		case field == "has" && op == ":":
+			q.Has = append(q.Has, column(value))
+		case field == "sort" && op == ":":
+			for _, f := range strings.Split(value, ",") {
+				desc := strings.HasPrefix(f, "-")
+				q.Sort = append(q.Sort, Sort{Field: column(strings.TrimPrefix(f, "-")), Desc: desc})
+			}
+		case field == "fields" && op == ":":
+			for _, f := range strings.Split(value, ",") {
+				q.Fields = append(q.Fields, column(f))
+			}
+		case field == "limit" && op == ":":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid limit: %q", value)
+			}
+			q.Limit = min(n, MaxLimit)
+		case field == "page" && op == ":":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid page: %q", value)
+			}
+			q.Page = n
+		default:
+			q.Filters = append(q.Filters, Filter{
+				Field:  column(field),
+				Op:     op,
+				Value:  value,
+				Negate: negate,
+			})
+		}
+	}
+
+	return q, nil
+}
+
+// tokenize splits on whitespace, keeping quoted strings together.
+func tokenize(s string) ([]string, error) {
+	var terms []string
+	var cur strings.Builder
+	quoted := false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if cur.Len() > 0 {
+				terms = append(terms, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in query")
+	}
+	if cur.Len() > 0 {
+		terms = append(terms, cur.String())
+	}
+
+	return terms, nil
+}
+
+// splitTerm splits field:value into its parts. op is empty for free text.
+func splitTerm(term string) (field, op, value string) {
+	if strings.HasPrefix(term, `"`) {
+		return "", "", ""
+	}
+
+	idx := strings.IndexAny(term, ":=!<>")
+	if idx <= 0 {
+		return "", "", ""
+	}
+
+	rest := term[idx:]
+	for _, o := range operators {
+		if strings.HasPrefix(rest, o) {
+			return term[:idx], o, rest[len(o):]
+		}
+	}
+
+	return "", "", ""
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func column(field string) string {
+	if c, ok := aliases[field]; ok {
+		return c
+	}
+	return field
+}
+
+// typedValue converts a query value into the type it is compared as.
+// Dates are compared as unix milliseconds, matching the index timestamps.
+func typedValue(value string) interface{} {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UnixMilli()
+		}
+	}
+	return value
+}
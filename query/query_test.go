@@ -0,0 +1,111 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *Query
+	}{
+		{
+			in:   "",
+			want: &Query{Limit: DefaultLimit, Page: 1},
+		},
+		{
+			in: `kind:Email from.email:"alice@" date>2024-01-01`,
+			want: &Query{
+				Filters: []Filter{
+					{Field: "schema_kind", Op: ":", Value: "Email"},
+					{Field: "from.email", Op: ":", Value: "alice@"},
+					{Field: "date", Op: ">", Value: "2024-01-01"},
+				},
+				Limit: DefaultLimit,
+				Page:  1,
+			},
+		},
+		{
+			in: "size>=10 size<=20 size<30 name=bob name!=alice -kind:Contact",
+			want: &Query{
+				Filters: []Filter{
+					{Field: "size", Op: ">=", Value: "10"},
+					{Field: "size", Op: "<=", Value: "20"},
+					{Field: "size", Op: "<", Value: "30"},
+					{Field: "name", Op: "=", Value: "bob"},
+					{Field: "name", Op: "!=", Value: "alice"},
+					{Field: "schema_kind", Op: ":", Value: "Contact", Negate: true},
+				},
+				Limit: DefaultLimit,
+				Page:  1,
+			},
+		},
+		{
+			in: `alice -spam "out of office" -"do not reply"`,
+			want: &Query{
+				Text: []Text{
+					{Value: "alice"},
+					{Value: "spam", Negate: true},
+					{Value: "out of office"},
+					{Value: "do not reply", Negate: true},
+				},
+				Limit: DefaultLimit,
+				Page:  1,
+			},
+		},
+		{
+			in: "has:attachments sort:-date,kind fields:subject,permanode limit:20 page:3",
+			want: &Query{
+				Has:    []string{"attachments"},
+				Sort:   []Sort{{Field: "date", Desc: true}, {Field: "schema_kind"}},
+				Fields: []string{"subject", "permanode_hash"},
+				Limit:  20,
+				Page:   3,
+			},
+		},
+		{
+			in:   "limit:5000",
+			want: &Query{Limit: MaxLimit, Page: 1},
+		},
+		{
+			in: `Name:"a:b" - -`,
+			want: &Query{
+				Filters: []Filter{{Field: "name", Op: ":", Value: "a:b"}},
+				Text:    []Text{{Value: "-"}, {Value: "-"}},
+				Limit:   DefaultLimit,
+				Page:    1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		`from:"alice`,
+		"limit:0",
+		"limit:many",
+		"page:0",
+		"page:-1",
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			if _, err := Parse(in); err == nil {
+				t.Errorf("Parse(%q) succeeded, want an error", in)
+			}
+		})
+	}
+}
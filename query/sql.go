@@ -0,0 +1,263 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"go.quinn.io/dataq/index"
+	"go.quinn.io/dataq/schema"
+)
+
+var identRe = regexp.MustCompile(`^[a-z_][a-z0-9_.]*$`)
+
+// exactColumns are matched with equality for field:value instead of a substring
+var exactColumns = map[string]bool{
+	"schema_kind":    true,
+	"permanode_hash": true,
+	"content_hash":   true,
+	"delete_hash":    true,
+}
+
+// Build compiles the query into a select from index_data.
+// columns are the columns of index_data, every field in the query must be one of them.
+func (q *Query) Build(idx *index.Index, columns []string) (sq.SelectBuilder, error) {
+	backend := idx.Backend()
+	known := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		known[c] = true
+	}
+
+	ident := func(field string) (string, error) {
+		if !identRe.MatchString(field) || !known[field] {
+			return "", fmt.Errorf("unknown field: %q", field)
+		}
+		return index.QuoteIdent(field), nil
+	}
+
+	// idents also matches a message field, e.g. from, with the fields it is
+	// indexed as, e.g. from.email and from.name
+	idents := func(field string) ([]string, error) {
+		if col, err := ident(field); err == nil {
+			return []string{col}, nil
+		}
+
+		var cols []string
+		for _, c := range columns {
+			if strings.HasPrefix(c, field+".") && identRe.MatchString(c) {
+				cols = append(cols, index.QuoteIdent(c))
+			}
+		}
+		if len(cols) == 0 {
+			return nil, fmt.Errorf("unknown field: %q", field)
+		}
+		return cols, nil
+	}
+
+	sel := idx.Q
+	for _, f := range q.Filters {
+		cols, err := idents(f.Field)
+		if err != nil {
+			return sel, err
+		}
+
+		var conds []sq.Sqlizer
+		for _, col := range cols {
+			var cond sq.Sqlizer
+			switch f.Op {
+			case ":":
+				if exactColumns[f.Field] {
+					cond = sq.Eq{col: f.Value}
+				} else {
+					cond = backend.Like(col, "%"+index.EscapeLike(f.Value)+"%")
+				}
+			case "=":
+				cond = sq.Eq{col: f.Value}
+			case "!=":
+				cond = sq.NotEq{col: f.Value}
+			case ">":
+				cond = sq.Gt{col: typedValue(f.Value)}
+			case ">=":
+				cond = sq.GtOrEq{col: typedValue(f.Value)}
+			case "<":
+				cond = sq.Lt{col: typedValue(f.Value)}
+			case "<=":
+				cond = sq.LtOrEq{col: typedValue(f.Value)}
+			default:
+				return sel, fmt.Errorf("unknown operator: %q", f.Op)
+			}
+			conds = append(conds, cond)
+		}
+
+		// Any of the fields of a message matches, except for != where none may
+		var cond sq.Sqlizer = sq.Or(conds)
+		switch {
+		case len(conds) == 1:
+			cond = conds[0]
+		case f.Op == "!=":
+			cond = sq.And(conds)
+		}
+		if f.Negate {
+			cond = not{cond}
+		}
+		sel = sel.Where(cond)
+	}
+
+	for _, field := range q.Has {
+		cols, err := idents(field)
+		if err != nil {
+			return sel, err
+		}
+
+		var or sq.Or
+		for _, col := range cols {
+			or = append(or, sq.And{sq.NotEq{col: nil}, backend.Like(col, "_%")})
+		}
+		if len(or) == 1 {
+			sel = sel.Where(or[0])
+		} else {
+			sel = sel.Where(or)
+		}
+	}
+
+	quoted := make([]string, len(columns))
+	for n, c := range columns {
+		quoted[n] = index.QuoteIdent(c)
+	}
+	for _, text := range q.Text {
+		cond := backend.Search(text.Value, quoted)
+		if text.Negate {
+			cond = not{cond}
+		}
+		sel = sel.Where(cond)
+	}
+
+	for _, field := range q.Fields {
+		if _, err := ident(field); err != nil {
+			return sel, err
+		}
+	}
+
+	if len(q.Sort) == 0 {
+		sel = sel.OrderBy("timestamp DESC")
+	}
+	for _, s := range q.Sort {
+		col, err := ident(s.Field)
+		if err != nil {
+			return sel, err
+		}
+		if s.Desc {
+			col += " DESC"
+		}
+		sel = sel.OrderBy(col)
+	}
+
+	return sel.
+		Limit(uint64(q.Limit)).
+		Offset(uint64((q.Page - 1) * q.Limit)), nil
+}
+
+// not negates a condition. Comparisons with NULL columns are false, so rows
+// without the field match the negation.
+type not struct {
+	sq.Sqlizer
+}
+
+func (n not) ToSql() (string, []interface{}, error) {
+	s, args, err := n.Sqlizer.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return "NOT COALESCE((" + s + "), FALSE)", args, nil
+}
+
+// Row is a single result, with metadata limited to the projected fields.
+type Row struct {
+	SchemaKind    string                 `json:"schema_kind"`
+	ContentHash   string                 `json:"content_hash,omitempty"`
+	PermanodeHash string                 `json:"permanode_hash,omitempty"`
+	Timestamp     time.Time              `json:"timestamp,omitzero"`
+	Fields        map[string]interface{} `json:"fields,omitempty"`
+}
+
+type Result struct {
+	Query *Query `json:"-"`
+	Rows  []Row  `json:"rows"`
+	Page  int    `json:"page"`
+	Limit int    `json:"limit"`
+}
+
+// Run parses and executes a query against the index.
+func Run(ctx context.Context, idx *index.Index, s string) (*Result, error) {
+	q, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Run(ctx, idx)
+}
+
+// Run executes a parsed query against the index.
+func (q *Query) Run(ctx context.Context, idx *index.Index) (*Result, error) {
+	var columns []string
+	for name, err := range idx.IterateFields(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+
+	result := &Result{Query: q, Page: q.Page, Limit: q.Limit}
+
+	// The index is empty
+	if len(columns) == 0 {
+		return result, nil
+	}
+
+	sel, err := q.Build(idx, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := idx.Query(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Rows = make([]Row, 0, len(claims))
+	for _, claim := range claims {
+		result.Rows = append(result.Rows, q.row(claim))
+	}
+
+	return result, nil
+}
+
+func (q *Query) row(claim schema.Claim) Row {
+	row := Row{
+		SchemaKind:    claim.SchemaKind,
+		ContentHash:   claim.ContentHash,
+		PermanodeHash: claim.PermanodeHash,
+		Timestamp:     claim.Timestamp,
+		Fields:        make(map[string]interface{}),
+	}
+
+	if len(q.Fields) == 0 {
+		for k, v := range claim.Metadata {
+			if v != nil {
+				row.Fields[k] = v
+			}
+		}
+		return row
+	}
+
+	for _, f := range q.Fields {
+		if v, ok := claim.Metadata[f]; ok && v != nil {
+			row.Fields[f] = v
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"go.quinn.io/dataq/index"
+)
+
+func TestBuild(t *testing.T) {
+	columns := []string{"schema_kind", "content_hash", "timestamp", "name", "size", "from.email", "from.name"}
+
+	tests := []struct {
+		in       string
+		backend  index.Backend
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			in:       "kind:Email",
+			wantSQL:  `SELECT * FROM index_data WHERE "schema_kind" = ? ORDER BY timestamp DESC LIMIT 50 OFFSET 0`,
+			wantArgs: []interface{}{"Email"},
+		},
+		{
+			in:       "name:100%_off size>10 -from.email:alice",
+			wantSQL:  `SELECT * FROM index_data WHERE "name" LIKE ? ESCAPE '\' AND "size" > ? AND NOT COALESCE(("from.email" LIKE ? ESCAPE '\'), FALSE) ORDER BY timestamp DESC LIMIT 50 OFFSET 0`,
+			wantArgs: []interface{}{`%100\%\_off%`, int64(10), "%alice%"},
+		},
+		{
+			in:       "-spam sort:name,-size limit:10 page:2",
+			wantSQL:  `SELECT * FROM index_data WHERE NOT COALESCE((("schema_kind" LIKE ? ESCAPE '\' OR "content_hash" LIKE ? ESCAPE '\' OR "timestamp" LIKE ? ESCAPE '\' OR "name" LIKE ? ESCAPE '\' OR "size" LIKE ? ESCAPE '\' OR "from.email" LIKE ? ESCAPE '\' OR "from.name" LIKE ? ESCAPE '\')), FALSE) ORDER BY "name", "size" DESC LIMIT 10 OFFSET 10`,
+			wantArgs: []interface{}{"%spam%", "%spam%", "%spam%", "%spam%", "%spam%", "%spam%", "%spam%"},
+		},
+		{
+			in:       `from:"alice@" -from:bob`,
+			wantSQL:  `SELECT * FROM index_data WHERE ("from.email" LIKE ? ESCAPE '\' OR "from.name" LIKE ? ESCAPE '\') AND NOT COALESCE((("from.email" LIKE ? ESCAPE '\' OR "from.name" LIKE ? ESCAPE '\')), FALSE) ORDER BY timestamp DESC LIMIT 50 OFFSET 0`,
+			wantArgs: []interface{}{"%alice@%", "%alice@%", "%bob%", "%bob%"},
+		},
+		{
+			in:       "from!=bob has:from",
+			wantSQL:  `SELECT * FROM index_data WHERE ("from.email" <> ? AND "from.name" <> ?) AND (("from.email" IS NOT NULL AND "from.email" LIKE ? ESCAPE '\') OR ("from.name" IS NOT NULL AND "from.name" LIKE ? ESCAPE '\')) ORDER BY timestamp DESC LIMIT 50 OFFSET 0`,
+			wantArgs: []interface{}{"bob", "bob", "_%", "_%"},
+		},
+		{
+			in:       "to:bob",
+			wantSQL:  "",
+			wantArgs: nil,
+		},
+		{
+			in:       "kind:Email missing:1",
+			wantSQL:  "",
+			wantArgs: nil,
+		},
+		{
+			in:       `name:a\b -"50%"`,
+			backend:  index.Postgres{},
+			wantSQL:  `SELECT * FROM index_data WHERE CAST("name" AS TEXT) ILIKE $1 ESCAPE '\' AND NOT COALESCE((_search @@ plainto_tsquery('simple', $2)), FALSE) ORDER BY timestamp DESC LIMIT 50 OFFSET 0`,
+			wantArgs: []interface{}{`%a\\b%`, "50%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			backend := tt.backend
+			if backend == nil {
+				backend = index.SQLite{}
+			}
+			idx := index.NewIndexWithBackend(nil, nil, backend)
+
+			q, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			sel, err := q.Build(idx, columns)
+			if tt.wantSQL == "" {
+				if err == nil {
+					t.Errorf("Build(%q) succeeded, want an error", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build(%q): %v", tt.in, err)
+			}
+
+			sql, args, err := sel.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %s\nwant  %s", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -9,6 +9,10 @@ package rpc
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +24,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Freshness_Policy int32
+
+const (
+	Freshness_ALWAYS Freshness_Policy = 0 // Call the plugin every time
+	Freshness_NEVER  Freshness_Policy = 1 // Reuse the response, e.g. for immutable data
+	Freshness_TTL    Freshness_Policy = 2 // Reuse the response until it is older than ttl
+)
+
+// Enum value maps for Freshness_Policy.
+var (
+	Freshness_Policy_name = map[int32]string{
+		0: "ALWAYS",
+		1: "NEVER",
+		2: "TTL",
+	}
+	Freshness_Policy_value = map[string]int32{
+		"ALWAYS": 0,
+		"NEVER":  1,
+		"TTL":    2,
+	}
+)
+
+func (x Freshness_Policy) Enum() *Freshness_Policy {
+	p := new(Freshness_Policy)
+	*p = x
+	return p
+}
+
+func (x Freshness_Policy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Freshness_Policy) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_dataq_proto_enumTypes[0].Descriptor()
+}
+
+func (Freshness_Policy) Type() protoreflect.EnumType {
+	return &file_rpc_dataq_proto_enumTypes[0]
+}
+
+func (x Freshness_Policy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Freshness_Policy.Descriptor instead.
+func (Freshness_Policy) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{1, 0}
+}
+
 type InstallRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PluginId      string                 `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
@@ -64,19 +117,79 @@ func (x *InstallRequest) GetPluginId() string {
 	return ""
 }
 
+// Freshness says when the host calls the plugin again for a request identical
+// to one it already has a response to, instead of reusing that response.
+// Requests are identical when they hash the same without their OAuth token.
+type Freshness struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        Freshness_Policy       `protobuf:"varint,1,opt,name=policy,proto3,enum=dataq.Freshness_Policy" json:"policy,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Freshness) Reset() {
+	*x = Freshness{}
+	mi := &file_rpc_dataq_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Freshness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Freshness) ProtoMessage() {}
+
+func (x *Freshness) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Freshness.ProtoReflect.Descriptor instead.
+func (*Freshness) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Freshness) GetPolicy() Freshness_Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return Freshness_ALWAYS
+}
+
+func (x *Freshness) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
 type InstallResponse struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	PluginId      string                     `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
-	Configs       []*PluginConfig            `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
-	Oauth         *OAuth2                    `protobuf:"bytes,3,opt,name=oauth,proto3" json:"oauth,omitempty"`
-	Extracts      []*InstallResponse_Extract `protobuf:"bytes,4,rep,name=extracts,proto3" json:"extracts,omitempty"`
+	state      protoimpl.MessageState       `protogen:"open.v1"`
+	PluginId   string                       `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	Configs    []*PluginConfig              `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
+	Oauth      *OAuth2                      `protobuf:"bytes,3,opt,name=oauth,proto3" json:"oauth,omitempty"`
+	Extracts   []*InstallResponse_Extract   `protobuf:"bytes,4,rep,name=extracts,proto3" json:"extracts,omitempty"`
+	Transforms []*InstallResponse_Transform `protobuf:"bytes,6,rep,name=transforms,proto3" json:"transforms,omitempty"`
+	// Descriptors of the messages the plugin returns as Any permanode payloads,
+	// including their dependencies. The host indexes these without having them
+	// compiled in.
+	Descriptors   *descriptorpb.FileDescriptorSet `protobuf:"bytes,5,opt,name=descriptors,proto3" json:"descriptors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstallResponse) Reset() {
 	*x = InstallResponse{}
-	mi := &file_rpc_dataq_proto_msgTypes[1]
+	mi := &file_rpc_dataq_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -88,7 +201,7 @@ func (x *InstallResponse) String() string {
 func (*InstallResponse) ProtoMessage() {}
 
 func (x *InstallResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[1]
+	mi := &file_rpc_dataq_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,7 +214,7 @@ func (x *InstallResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallResponse.ProtoReflect.Descriptor instead.
 func (*InstallResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{1}
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *InstallResponse) GetPluginId() string {
@@ -132,6 +245,20 @@ func (x *InstallResponse) GetExtracts() []*InstallResponse_Extract {
 	return nil
 }
 
+func (x *InstallResponse) GetTransforms() []*InstallResponse_Transform {
+	if x != nil {
+		return x.Transforms
+	}
+	return nil
+}
+
+func (x *InstallResponse) GetDescriptors() *descriptorpb.FileDescriptorSet {
+	if x != nil {
+		return x.Descriptors
+	}
+	return nil
+}
+
 type PluginConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -142,7 +269,7 @@ type PluginConfig struct {
 
 func (x *PluginConfig) Reset() {
 	*x = PluginConfig{}
-	mi := &file_rpc_dataq_proto_msgTypes[2]
+	mi := &file_rpc_dataq_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +281,7 @@ func (x *PluginConfig) String() string {
 func (*PluginConfig) ProtoMessage() {}
 
 func (x *PluginConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[2]
+	mi := &file_rpc_dataq_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +294,7 @@ func (x *PluginConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginConfig.ProtoReflect.Descriptor instead.
 func (*PluginConfig) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{2}
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PluginConfig) GetKey() string {
@@ -184,21 +311,25 @@ func (x *PluginConfig) GetLabel() string {
 	return ""
 }
 
-// ExtractRequest contains information about what data to extract
+// ExtractRequest contains information about what data to extract. Version 1
+// named instance_hash plugin_id.
 type ExtractRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Oauth         *OAuth2                `protobuf:"bytes,5,opt,name=oauth,proto3" json:"oauth,omitempty"`
-	PluginId      string                 `protobuf:"bytes,4,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`       // Plugin to use for extraction
-	ParentHash    string                 `protobuf:"bytes,1,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"` // Content address of the object responsible for creating the Extract
-	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                               // Operation to be performed that will produce data
-	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Oauth        *OAuth2                `protobuf:"bytes,5,opt,name=oauth,proto3" json:"oauth,omitempty"`                                   // Attached when sent, never indexed
+	InstanceHash string                 `protobuf:"bytes,4,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"` // Address of the PluginInstance the extract is for
+	ParentHash   string                 `protobuf:"bytes,1,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`       // Content address of the object responsible for creating the Extract
+	Kind         string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                                     // Operation to be performed that will produce data
+	Metadata     map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Sync state of the plugin instance, e.g. the cursor an incremental sync
+	// continues from. Attached when sent, never indexed.
+	State         map[string][]byte `protobuf:"bytes,6,rep,name=state,proto3" json:"state,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExtractRequest) Reset() {
 	*x = ExtractRequest{}
-	mi := &file_rpc_dataq_proto_msgTypes[3]
+	mi := &file_rpc_dataq_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +341,7 @@ func (x *ExtractRequest) String() string {
 func (*ExtractRequest) ProtoMessage() {}
 
 func (x *ExtractRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[3]
+	mi := &file_rpc_dataq_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,7 +354,7 @@ func (x *ExtractRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExtractRequest.ProtoReflect.Descriptor instead.
 func (*ExtractRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{3}
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ExtractRequest) GetOauth() *OAuth2 {
@@ -233,9 +364,9 @@ func (x *ExtractRequest) GetOauth() *OAuth2 {
 	return nil
 }
 
-func (x *ExtractRequest) GetPluginId() string {
+func (x *ExtractRequest) GetInstanceHash() string {
 	if x != nil {
-		return x.PluginId
+		return x.InstanceHash
 	}
 	return ""
 }
@@ -261,6 +392,13 @@ func (x *ExtractRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *ExtractRequest) GetState() map[string][]byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
 // ExtractResponse contains the result of an extraction
 type ExtractResponse struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
@@ -270,15 +408,20 @@ type ExtractResponse struct {
 	//
 	//	*ExtractResponse_Hash
 	//	*ExtractResponse_Content
-	Data          isExtractResponse_Data       `protobuf_oneof:"data"`
-	Transforms    []*ExtractResponse_Transform `protobuf:"bytes,4,rep,name=transforms,proto3" json:"transforms,omitempty"` // List of transform requests to be created
+	Data       isExtractResponse_Data       `protobuf_oneof:"data"`
+	Transforms []*ExtractResponse_Transform `protobuf:"bytes,4,rep,name=transforms,proto3" json:"transforms,omitempty"`                   // List of transform requests to be created
+	ReceivedAt *timestamppb.Timestamp       `protobuf:"bytes,7,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"` // Set by the host when the plugin responds
+	// Sync state keys to update once the response is stored, keys with an empty
+	// value are removed. Keys that aren't set keep their value. The state set by
+	// the items of a stream is applied once the stream ends.
+	State         map[string][]byte `protobuf:"bytes,8,rep,name=state,proto3" json:"state,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExtractResponse) Reset() {
 	*x = ExtractResponse{}
-	mi := &file_rpc_dataq_proto_msgTypes[4]
+	mi := &file_rpc_dataq_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -290,7 +433,7 @@ func (x *ExtractResponse) String() string {
 func (*ExtractResponse) ProtoMessage() {}
 
 func (x *ExtractResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[4]
+	mi := &file_rpc_dataq_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +446,7 @@ func (x *ExtractResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExtractResponse.ProtoReflect.Descriptor instead.
 func (*ExtractResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{4}
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ExtractResponse) GetKind() string {
@@ -352,6 +495,20 @@ func (x *ExtractResponse) GetTransforms() []*ExtractResponse_Transform {
 	return nil
 }
 
+func (x *ExtractResponse) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *ExtractResponse) GetState() map[string][]byte {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
 type isExtractResponse_Data interface {
 	isExtractResponse_Data()
 }
@@ -361,31 +518,35 @@ type ExtractResponse_Hash struct {
 }
 
 type ExtractResponse_Content struct {
-	Content []byte `protobuf:"bytes,6,opt,name=content,proto3,oneof"` // Extracted data
+	Content []byte `protobuf:"bytes,6,opt,name=content,proto3,oneof"` // Extracted data, stored in the CAS
 }
 
 func (*ExtractResponse_Hash) isExtractResponse_Data() {}
 
 func (*ExtractResponse_Content) isExtractResponse_Data() {}
 
-// TransformRequest represents an action to be performed on data
+// TransformRequest represents an action to be performed on data. Version 1
+// named instance_hash plugin_id.
 type TransformRequest struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	PluginId string                 `protobuf:"bytes,4,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"` // Plugin to use for transformation
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	InstanceHash string                 `protobuf:"bytes,4,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"` // Address of the PluginInstance the transform is for
 	// Types that are valid to be assigned to Data:
 	//
 	//	*TransformRequest_Hash
 	//	*TransformRequest_Content
-	Data          isTransformRequest_Data `protobuf_oneof:"data"`
-	Kind          string                  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // Kind of transform to be applied
-	Metadata      map[string]string       `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Data isTransformRequest_Data `protobuf_oneof:"data"`
+	// Address of the content, set when the host sends content. Not indexed,
+	// stored requests have the address in hash, indexed as data_hash.
+	DataHash      string            `protobuf:"bytes,7,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	Kind          string            `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // Kind of transform to be applied
+	Metadata      map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransformRequest) Reset() {
 	*x = TransformRequest{}
-	mi := &file_rpc_dataq_proto_msgTypes[5]
+	mi := &file_rpc_dataq_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -397,7 +558,7 @@ func (x *TransformRequest) String() string {
 func (*TransformRequest) ProtoMessage() {}
 
 func (x *TransformRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[5]
+	mi := &file_rpc_dataq_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,12 +571,12 @@ func (x *TransformRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransformRequest.ProtoReflect.Descriptor instead.
 func (*TransformRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{5}
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *TransformRequest) GetPluginId() string {
+func (x *TransformRequest) GetInstanceHash() string {
 	if x != nil {
-		return x.PluginId
+		return x.InstanceHash
 	}
 	return ""
 }
@@ -445,6 +606,13 @@ func (x *TransformRequest) GetContent() []byte {
 	return nil
 }
 
+func (x *TransformRequest) GetDataHash() string {
+	if x != nil {
+		return x.DataHash
+	}
+	return ""
+}
+
 func (x *TransformRequest) GetKind() string {
 	if x != nil {
 		return x.Kind
@@ -468,7 +636,7 @@ type TransformRequest_Hash struct {
 }
 
 type TransformRequest_Content struct {
-	Content []byte `protobuf:"bytes,6,opt,name=content,proto3,oneof"` // Extracted data
+	Content []byte `protobuf:"bytes,6,opt,name=content,proto3,oneof"` // Extracted data, stored in the CAS
 }
 
 func (*TransformRequest_Hash) isTransformRequest_Data() {}
@@ -477,18 +645,21 @@ func (*TransformRequest_Content) isTransformRequest_Data() {}
 
 // TransformResponse contains the result of a transform operation
 type TransformResponse struct {
-	state         protoimpl.MessageState         `protogen:"open.v1"`
-	Kind          string                         `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                                  // Kind from request
-	RequestHash   string                         `protobuf:"bytes,3,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"` // Address of request
-	Extracts      []*TransformResponse_Extract   `protobuf:"bytes,4,rep,name=extracts,proto3" json:"extracts,omitempty"`                          // List of extracts to be performed
-	Permanodes    []*TransformResponse_Permanode `protobuf:"bytes,5,rep,name=permanodes,proto3" json:"permanodes,omitempty"`                      // List of permanodes to be managed
+	state       protoimpl.MessageState       `protogen:"open.v1"`
+	Kind        string                       `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                                  // Kind from request
+	RequestHash string                       `protobuf:"bytes,3,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"` // Address of request
+	Extracts    []*TransformResponse_Extract `protobuf:"bytes,4,rep,name=extracts,proto3" json:"extracts,omitempty"`                          // List of extracts to be performed
+	// List of permanodes to be managed. Not indexed, the permanodes are indexed
+	// with their own kind.
+	Permanodes    []*TransformResponse_Permanode `protobuf:"bytes,5,rep,name=permanodes,proto3" json:"permanodes,omitempty"`
+	ReceivedAt    *timestamppb.Timestamp         `protobuf:"bytes,6,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"` // Set by the host when the plugin responds
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransformResponse) Reset() {
 	*x = TransformResponse{}
-	mi := &file_rpc_dataq_proto_msgTypes[6]
+	mi := &file_rpc_dataq_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -500,7 +671,7 @@ func (x *TransformResponse) String() string {
 func (*TransformResponse) ProtoMessage() {}
 
 func (x *TransformResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[6]
+	mi := &file_rpc_dataq_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,7 +684,7 @@ func (x *TransformResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransformResponse.ProtoReflect.Descriptor instead.
 func (*TransformResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{6}
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TransformResponse) GetKind() string {
@@ -544,31 +715,36 @@ func (x *TransformResponse) GetPermanodes() []*TransformResponse_Permanode {
 	return nil
 }
 
-type InstallResponse_Extract struct {
+func (x *TransformResponse) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+type GetBlobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
-	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Configs       []*PluginConfig        `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
+	InstanceHash  string                 `protobuf:"bytes,1,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"` // PluginInstance making the request
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstallResponse_Extract) Reset() {
-	*x = InstallResponse_Extract{}
-	mi := &file_rpc_dataq_proto_msgTypes[7]
+func (x *GetBlobRequest) Reset() {
+	*x = GetBlobRequest{}
+	mi := &file_rpc_dataq_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstallResponse_Extract) String() string {
+func (x *GetBlobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstallResponse_Extract) ProtoMessage() {}
+func (*GetBlobRequest) ProtoMessage() {}
 
-func (x *InstallResponse_Extract) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[7]
+func (x *GetBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -579,63 +755,92 @@ func (x *InstallResponse_Extract) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstallResponse_Extract.ProtoReflect.Descriptor instead.
-func (*InstallResponse_Extract) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use GetBlobRequest.ProtoReflect.Descriptor instead.
+func (*GetBlobRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *InstallResponse_Extract) GetKind() string {
+func (x *GetBlobRequest) GetInstanceHash() string {
 	if x != nil {
-		return x.Kind
+		return x.InstanceHash
 	}
 	return ""
 }
 
-func (x *InstallResponse_Extract) GetLabel() string {
+func (x *GetBlobRequest) GetHash() string {
 	if x != nil {
-		return x.Label
+		return x.Hash
 	}
 	return ""
 }
 
-func (x *InstallResponse_Extract) GetDescription() string {
+type GetBlobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlobResponse) Reset() {
+	*x = GetBlobResponse{}
+	mi := &file_rpc_dataq_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlobResponse) ProtoMessage() {}
+
+func (x *GetBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[9]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *InstallResponse_Extract) GetConfigs() []*PluginConfig {
+// Deprecated: Use GetBlobResponse.ProtoReflect.Descriptor instead.
+func (*GetBlobResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetBlobResponse) GetContent() []byte {
 	if x != nil {
-		return x.Configs
+		return x.Content
 	}
 	return nil
 }
 
-// Transform defines a transform operation to be performed
-type ExtractResponse_Transform struct {
+type PutBlobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                                                                   // Name of the transform
-	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional information for the transform
+	InstanceHash  string                 `protobuf:"bytes,1,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExtractResponse_Transform) Reset() {
-	*x = ExtractResponse_Transform{}
-	mi := &file_rpc_dataq_proto_msgTypes[9]
+func (x *PutBlobRequest) Reset() {
+	*x = PutBlobRequest{}
+	mi := &file_rpc_dataq_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExtractResponse_Transform) String() string {
+func (x *PutBlobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExtractResponse_Transform) ProtoMessage() {}
+func (*PutBlobRequest) ProtoMessage() {}
 
-func (x *ExtractResponse_Transform) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[9]
+func (x *PutBlobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,49 +851,47 @@ func (x *ExtractResponse_Transform) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExtractResponse_Transform.ProtoReflect.Descriptor instead.
-func (*ExtractResponse_Transform) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{4, 0}
+// Deprecated: Use PutBlobRequest.ProtoReflect.Descriptor instead.
+func (*PutBlobRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ExtractResponse_Transform) GetKind() string {
+func (x *PutBlobRequest) GetInstanceHash() string {
 	if x != nil {
-		return x.Kind
+		return x.InstanceHash
 	}
 	return ""
 }
 
-func (x *ExtractResponse_Transform) GetMetadata() map[string]string {
+func (x *PutBlobRequest) GetContent() []byte {
 	if x != nil {
-		return x.Metadata
+		return x.Content
 	}
 	return nil
 }
 
-// Extract defines an extract operation to be performed
-type TransformResponse_Extract struct {
+type PutBlobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                                                                   // Operation to perform
-	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Data necessary to perform the operation
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TransformResponse_Extract) Reset() {
-	*x = TransformResponse_Extract{}
-	mi := &file_rpc_dataq_proto_msgTypes[12]
+func (x *PutBlobResponse) Reset() {
+	*x = PutBlobResponse{}
+	mi := &file_rpc_dataq_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TransformResponse_Extract) String() string {
+func (x *PutBlobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransformResponse_Extract) ProtoMessage() {}
+func (*PutBlobResponse) ProtoMessage() {}
 
-func (x *TransformResponse_Extract) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[12]
+func (x *PutBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,53 +902,45 @@ func (x *TransformResponse_Extract) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransformResponse_Extract.ProtoReflect.Descriptor instead.
-func (*TransformResponse_Extract) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use PutBlobResponse.ProtoReflect.Descriptor instead.
+func (*PutBlobResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *TransformResponse_Extract) GetKind() string {
+func (x *PutBlobResponse) GetHash() string {
 	if x != nil {
-		return x.Kind
+		return x.Hash
 	}
 	return ""
 }
 
-func (x *TransformResponse_Extract) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type TransformResponse_Permanode struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Kind  string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // Kind of permanode to be managed
-	Key   string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`   // Value to uniquely identify the permanode within the plugin
-	// Types that are valid to be assigned to Payload:
-	//
-	//	*TransformResponse_Permanode_Email
-	//	*TransformResponse_Permanode_FinancialTransaction
-	Payload       isTransformResponse_Permanode_Payload `protobuf_oneof:"payload"`
+type QueryOwnPermanodesRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	InstanceHash string                 `protobuf:"bytes,1,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"`
+	Kind         string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // Only permanodes of this kind, if set
+	Key          string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`   // Only the permanode with this key, if set
+	// Index columns of the latest content and the values they must equal
+	Filters       map[string]string `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Limit         int32             `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"` // 100 if zero, at most 1000
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TransformResponse_Permanode) Reset() {
-	*x = TransformResponse_Permanode{}
-	mi := &file_rpc_dataq_proto_msgTypes[13]
+func (x *QueryOwnPermanodesRequest) Reset() {
+	*x = QueryOwnPermanodesRequest{}
+	mi := &file_rpc_dataq_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TransformResponse_Permanode) String() string {
+func (x *QueryOwnPermanodesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransformResponse_Permanode) ProtoMessage() {}
+func (*QueryOwnPermanodesRequest) ProtoMessage() {}
 
-func (x *TransformResponse_Permanode) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_dataq_proto_msgTypes[13]
+func (x *QueryOwnPermanodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,51 +951,718 @@ func (x *TransformResponse_Permanode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransformResponse_Permanode.ProtoReflect.Descriptor instead.
-func (*TransformResponse_Permanode) Descriptor() ([]byte, []int) {
-	return file_rpc_dataq_proto_rawDescGZIP(), []int{6, 1}
+// Deprecated: Use QueryOwnPermanodesRequest.ProtoReflect.Descriptor instead.
+func (*QueryOwnPermanodesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *TransformResponse_Permanode) GetKind() string {
+func (x *QueryOwnPermanodesRequest) GetInstanceHash() string {
 	if x != nil {
-		return x.Kind
+		return x.InstanceHash
 	}
 	return ""
 }
 
-func (x *TransformResponse_Permanode) GetKey() string {
+func (x *QueryOwnPermanodesRequest) GetKind() string {
 	if x != nil {
-		return x.Key
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *TransformResponse_Permanode) GetPayload() isTransformResponse_Permanode_Payload {
+func (x *QueryOwnPermanodesRequest) GetKey() string {
 	if x != nil {
-		return x.Payload
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-func (x *TransformResponse_Permanode) GetEmail() *Email {
+func (x *QueryOwnPermanodesRequest) GetFilters() map[string]string {
 	if x != nil {
-		if x, ok := x.Payload.(*TransformResponse_Permanode_Email); ok {
-			return x.Email
-		}
+		return x.Filters
 	}
 	return nil
 }
 
-func (x *TransformResponse_Permanode) GetFinancialTransaction() *FinancialTransaction {
+func (x *QueryOwnPermanodesRequest) GetLimit() int32 {
 	if x != nil {
-		if x, ok := x.Payload.(*TransformResponse_Permanode_FinancialTransaction); ok {
-			return x.FinancialTransaction
-		}
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type isTransformResponse_Permanode_Payload interface {
+type QueryOwnPermanodesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most recently updated first
+	Permanodes    []*QueryOwnPermanodesResponse_Permanode `protobuf:"bytes,1,rep,name=permanodes,proto3" json:"permanodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryOwnPermanodesResponse) Reset() {
+	*x = QueryOwnPermanodesResponse{}
+	mi := &file_rpc_dataq_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryOwnPermanodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryOwnPermanodesResponse) ProtoMessage() {}
+
+func (x *QueryOwnPermanodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryOwnPermanodesResponse.ProtoReflect.Descriptor instead.
+func (*QueryOwnPermanodesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueryOwnPermanodesResponse) GetPermanodes() []*QueryOwnPermanodesResponse_Permanode {
+	if x != nil {
+		return x.Permanodes
+	}
+	return nil
+}
+
+type GetPluginStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceHash  string                 `protobuf:"bytes,1,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPluginStateRequest) Reset() {
+	*x = GetPluginStateRequest{}
+	mi := &file_rpc_dataq_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPluginStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPluginStateRequest) ProtoMessage() {}
+
+func (x *GetPluginStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPluginStateRequest.ProtoReflect.Descriptor instead.
+func (*GetPluginStateRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPluginStateRequest) GetInstanceHash() string {
+	if x != nil {
+		return x.InstanceHash
+	}
+	return ""
+}
+
+func (x *GetPluginStateRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetPluginStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPluginStateResponse) Reset() {
+	*x = GetPluginStateResponse{}
+	mi := &file_rpc_dataq_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPluginStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPluginStateResponse) ProtoMessage() {}
+
+func (x *GetPluginStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPluginStateResponse.ProtoReflect.Descriptor instead.
+func (*GetPluginStateResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetPluginStateResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *GetPluginStateResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type SetPluginStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceHash  string                 `protobuf:"bytes,1,opt,name=instance_hash,json=instanceHash,proto3" json:"instance_hash,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPluginStateRequest) Reset() {
+	*x = SetPluginStateRequest{}
+	mi := &file_rpc_dataq_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPluginStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPluginStateRequest) ProtoMessage() {}
+
+func (x *SetPluginStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPluginStateRequest.ProtoReflect.Descriptor instead.
+func (*SetPluginStateRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetPluginStateRequest) GetInstanceHash() string {
+	if x != nil {
+		return x.InstanceHash
+	}
+	return ""
+}
+
+func (x *SetPluginStateRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetPluginStateRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type SetPluginStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPluginStateResponse) Reset() {
+	*x = SetPluginStateResponse{}
+	mi := &file_rpc_dataq_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPluginStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPluginStateResponse) ProtoMessage() {}
+
+func (x *SetPluginStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPluginStateResponse.ProtoReflect.Descriptor instead.
+func (*SetPluginStateResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{17}
+}
+
+type InstallResponse_Extract struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Kind        string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Label       string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Configs     []*PluginConfig        `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
+	Freshness   *Freshness             `protobuf:"bytes,5,opt,name=freshness,proto3" json:"freshness,omitempty"`
+	// The host calls ExtractStream for the kind instead of Extract
+	Stream        bool `protobuf:"varint,6,opt,name=stream,proto3" json:"stream,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallResponse_Extract) Reset() {
+	*x = InstallResponse_Extract{}
+	mi := &file_rpc_dataq_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallResponse_Extract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallResponse_Extract) ProtoMessage() {}
+
+func (x *InstallResponse_Extract) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallResponse_Extract.ProtoReflect.Descriptor instead.
+func (*InstallResponse_Extract) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *InstallResponse_Extract) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *InstallResponse_Extract) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *InstallResponse_Extract) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InstallResponse_Extract) GetConfigs() []*PluginConfig {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *InstallResponse_Extract) GetFreshness() *Freshness {
+	if x != nil {
+		return x.Freshness
+	}
+	return nil
+}
+
+func (x *InstallResponse_Extract) GetStream() bool {
+	if x != nil {
+		return x.Stream
+	}
+	return false
+}
+
+// Transform kinds the plugin handles, declared for their freshness
+type InstallResponse_Transform struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Freshness     *Freshness             `protobuf:"bytes,2,opt,name=freshness,proto3" json:"freshness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallResponse_Transform) Reset() {
+	*x = InstallResponse_Transform{}
+	mi := &file_rpc_dataq_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallResponse_Transform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallResponse_Transform) ProtoMessage() {}
+
+func (x *InstallResponse_Transform) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallResponse_Transform.ProtoReflect.Descriptor instead.
+func (*InstallResponse_Transform) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *InstallResponse_Transform) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *InstallResponse_Transform) GetFreshness() *Freshness {
+	if x != nil {
+		return x.Freshness
+	}
+	return nil
+}
+
+// Transform defines a transform operation to be performed
+type ExtractResponse_Transform struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                                                                   // Name of the transform
+	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional information for the transform
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractResponse_Transform) Reset() {
+	*x = ExtractResponse_Transform{}
+	mi := &file_rpc_dataq_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractResponse_Transform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractResponse_Transform) ProtoMessage() {}
+
+func (x *ExtractResponse_Transform) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractResponse_Transform.ProtoReflect.Descriptor instead.
+func (*ExtractResponse_Transform) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *ExtractResponse_Transform) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ExtractResponse_Transform) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Extract defines an extract operation to be performed
+type TransformResponse_Extract struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                                                                   // Operation to perform
+	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Data necessary to perform the operation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransformResponse_Extract) Reset() {
+	*x = TransformResponse_Extract{}
+	mi := &file_rpc_dataq_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransformResponse_Extract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransformResponse_Extract) ProtoMessage() {}
+
+func (x *TransformResponse_Extract) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransformResponse_Extract.ProtoReflect.Descriptor instead.
+func (*TransformResponse_Extract) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *TransformResponse_Extract) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TransformResponse_Extract) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type TransformResponse_Permanode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // Kind of permanode to be managed
+	Key   string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`   // Value to uniquely identify the permanode within the plugin
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*TransformResponse_Permanode_Email
+	//	*TransformResponse_Permanode_FinancialTransaction
+	//	*TransformResponse_Permanode_Contact
+	//	*TransformResponse_Permanode_CalendarEvent
+	//	*TransformResponse_Permanode_MediaItem
+	//	*TransformResponse_Permanode_LocationPoint
+	//	*TransformResponse_Permanode_Document
+	//	*TransformResponse_Permanode_HealthMetric
+	//	*TransformResponse_Permanode_ChatMessage
+	//	*TransformResponse_Permanode_Bookmark
+	//	*TransformResponse_Permanode_Attachment
+	//	*TransformResponse_Permanode_Any
+	Payload       isTransformResponse_Permanode_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransformResponse_Permanode) Reset() {
+	*x = TransformResponse_Permanode{}
+	mi := &file_rpc_dataq_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransformResponse_Permanode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransformResponse_Permanode) ProtoMessage() {}
+
+func (x *TransformResponse_Permanode) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransformResponse_Permanode.ProtoReflect.Descriptor instead.
+func (*TransformResponse_Permanode) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *TransformResponse_Permanode) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TransformResponse_Permanode) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TransformResponse_Permanode) GetPayload() isTransformResponse_Permanode_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetEmail() *Email {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_Email); ok {
+			return x.Email
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetFinancialTransaction() *FinancialTransaction {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_FinancialTransaction); ok {
+			return x.FinancialTransaction
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetContact() *Contact {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_Contact); ok {
+			return x.Contact
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetCalendarEvent() *CalendarEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_CalendarEvent); ok {
+			return x.CalendarEvent
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetMediaItem() *MediaItem {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_MediaItem); ok {
+			return x.MediaItem
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetLocationPoint() *LocationPoint {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_LocationPoint); ok {
+			return x.LocationPoint
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetDocument() *Document {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_Document); ok {
+			return x.Document
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetHealthMetric() *HealthMetric {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_HealthMetric); ok {
+			return x.HealthMetric
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetChatMessage() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_ChatMessage); ok {
+			return x.ChatMessage
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetBookmark() *Bookmark {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_Bookmark); ok {
+			return x.Bookmark
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *TransformResponse_Permanode) GetAny() *anypb.Any {
+	if x != nil {
+		if x, ok := x.Payload.(*TransformResponse_Permanode_Any); ok {
+			return x.Any
+		}
+	}
+	return nil
+}
+
+type isTransformResponse_Permanode_Payload interface {
 	isTransformResponse_Permanode_Payload()
 }
 
@@ -812,49 +1674,224 @@ type TransformResponse_Permanode_FinancialTransaction struct {
 	FinancialTransaction *FinancialTransaction `protobuf:"bytes,7,opt,name=financial_transaction,json=financialTransaction,proto3,oneof"`
 }
 
+type TransformResponse_Permanode_Contact struct {
+	Contact *Contact `protobuf:"bytes,9,opt,name=contact,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_CalendarEvent struct {
+	CalendarEvent *CalendarEvent `protobuf:"bytes,10,opt,name=calendar_event,json=calendarEvent,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_MediaItem struct {
+	MediaItem *MediaItem `protobuf:"bytes,11,opt,name=media_item,json=mediaItem,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_LocationPoint struct {
+	LocationPoint *LocationPoint `protobuf:"bytes,12,opt,name=location_point,json=locationPoint,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_Document struct {
+	Document *Document `protobuf:"bytes,13,opt,name=document,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_HealthMetric struct {
+	HealthMetric *HealthMetric `protobuf:"bytes,14,opt,name=health_metric,json=healthMetric,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_ChatMessage struct {
+	ChatMessage *ChatMessage `protobuf:"bytes,15,opt,name=chat_message,json=chatMessage,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_Bookmark struct {
+	Bookmark *Bookmark `protobuf:"bytes,16,opt,name=bookmark,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,17,opt,name=attachment,proto3,oneof"`
+}
+
+type TransformResponse_Permanode_Any struct {
+	// Any message described by InstallResponse.descriptors
+	Any *anypb.Any `protobuf:"bytes,8,opt,name=any,proto3,oneof"`
+}
+
 func (*TransformResponse_Permanode_Email) isTransformResponse_Permanode_Payload() {}
 
 func (*TransformResponse_Permanode_FinancialTransaction) isTransformResponse_Permanode_Payload() {}
 
+func (*TransformResponse_Permanode_Contact) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_CalendarEvent) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_MediaItem) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_LocationPoint) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_Document) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_HealthMetric) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_ChatMessage) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_Bookmark) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_Attachment) isTransformResponse_Permanode_Payload() {}
+
+func (*TransformResponse_Permanode_Any) isTransformResponse_Permanode_Payload() {}
+
+type QueryOwnPermanodesResponse_Permanode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"` // Address of the permanode
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"` // Latest content as canonical JSON
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) Reset() {
+	*x = QueryOwnPermanodesResponse_Permanode{}
+	mi := &file_rpc_dataq_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryOwnPermanodesResponse_Permanode) ProtoMessage() {}
+
+func (x *QueryOwnPermanodesResponse_Permanode) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_dataq_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryOwnPermanodesResponse_Permanode.ProtoReflect.Descriptor instead.
+func (*QueryOwnPermanodesResponse_Permanode) Descriptor() ([]byte, []int) {
+	return file_rpc_dataq_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *QueryOwnPermanodesResponse_Permanode) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 var File_rpc_dataq_proto protoreflect.FileDescriptor
 
 var file_rpc_dataq_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x05, 0x64, 0x61, 0x74, 0x61, 0x71, 0x1a, 0x10, 0x72, 0x70, 0x63, 0x2f, 0x73, 0x63,
 	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x72, 0x70, 0x63, 0x2f,
-	0x6f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a, 0x0e,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x64, 0x22, 0xc5, 0x02, 0x0a, 0x0f,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x72, 0x70,
+	0x63, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a,
+	0x0e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x07,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6f,
-	0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x71, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x32, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68,
-	0x12, 0x3a, 0x0a, 0x08, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x52, 0x08, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x1a, 0x84, 0x01, 0x0a,
-	0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x50, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x73, 0x22, 0x36, 0x0a, 0x0c, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x93, 0x01, 0x0a,
+	0x09, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x71, 0x2e, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2b, 0x0a, 0x03, 0x74,
+	0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x28, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x4c, 0x57, 0x41, 0x59, 0x53, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x4e, 0x45, 0x56, 0x45, 0x52, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x54, 0x4c,
+	0x10, 0x02, 0x22, 0xee, 0x04, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x32,
+	0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x3a, 0x0a, 0x08, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x08, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x73, 0x12, 0x4c, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x42, 0x06,
+	0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x73, 0x1a, 0xcc, 0x01, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x71, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x52,
+	0x09, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x1a, 0x4f, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x46,
+	0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x09, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x73, 0x73, 0x22, 0x36, 0x0a, 0x0c, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x80, 0x02, 0x0a, 0x0e,
-	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x92, 0x03, 0x0a, 0x0e,
+	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
 	0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x32, 0x52, 0x00, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
+	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x32, 0x42, 0x06, 0xa2, 0xbb,
+	0x18, 0x02, 0x08, 0x01, 0x52, 0x00, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
 	0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04,
 	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
@@ -862,99 +1899,255 @@ var file_rpc_dataq_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61,
 	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
 	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x61, 0x12, 0x3e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
 	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xef,
-	0x02, 0x0a, 0x0f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
-	0x1a, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c,
-	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x0a, 0x74,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x1a, 0xa8, 0x01,
-	0x0a, 0x09, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6b,
-	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
-	0x4a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x6f, 0x72, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x38,
+	0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
 	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x22, 0xfd, 0x01, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f,
-	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x49, 0x64, 0x12, 0x14, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1a, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x41, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x22, 0xae, 0x04, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3c, 0x0a,
-	0x08, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
-	0x74, 0x52, 0x08, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70,
-	0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x22, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e,
-	0x6f, 0x64, 0x65, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a,
-	0xa6, 0x01, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
-	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
-	0x4a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xb6, 0x01, 0x0a, 0x09, 0x50, 0x65, 0x72,
-	0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x05,
-	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x71, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x12, 0x52, 0x0a, 0x15, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x5f,
-	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6e, 0x63,
-	0x69, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
-	0x52, 0x14, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x32, 0xc7, 0x01, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x51, 0x50, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x12, 0x3a, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x12, 0x15, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x71, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a,
-	0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71,
-	0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x17, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x67,
-	0x6f, 0x2e, 0x71, 0x75, 0x69, 0x6e, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x71,
-	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x06, 0xb2, 0xbb, 0x18, 0x02, 0x08, 0x02,
+	0x22, 0xaf, 0x04, 0x0a, 0x0f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x22, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x0a, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x3f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x1a, 0xa8, 0x01, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x6f, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x4a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x38, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xba, 0x02, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x12, 0x22, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0c, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x48, 0x00, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08,
+	0x01, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x41, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a,
+	0x06, 0xb2, 0xbb, 0x18, 0x02, 0x08, 0x02, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x82, 0x09, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3c, 0x0a, 0x08,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x52, 0x08, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x0a, 0x70, 0x65,
+	0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f,
+	0x64, 0x65, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d,
+	0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x64, 0x41, 0x74, 0x1a, 0xa6, 0x01, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x4a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a,
+	0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xc5, 0x05, 0x0a,
+	0x09, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x24, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x52, 0x0a, 0x15, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63,
+	0x69, 0x61, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x46, 0x69,
+	0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x71, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x3d, 0x0a, 0x0e, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64,
+	0x61, 0x72, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x69,
+	0x74, 0x65, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x48, 0x00, 0x52, 0x09, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x3d, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x48, 0x00, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x12, 0x37, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b,
+	0x63, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x62,
+	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x48, 0x00,
+	0x52, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x33, 0x0a, 0x0a, 0x61, 0x74,
+	0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x28, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
+	0x6e, 0x79, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6e, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0x49, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22,
+	0x2b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x57, 0x0a, 0x0e,
+	0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x42, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x25, 0x0a, 0x0f, 0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x62,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x81, 0x02, 0x0a,
+	0x19, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x77, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x47, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x4f, 0x77, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x86, 0x02, 0x0a, 0x1a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x77, 0x6e, 0x50, 0x65, 0x72,
+	0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4b, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x4f, 0x77, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65,
+	0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x9a, 0x01, 0x0a,
+	0x09, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4e, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x44, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x22,
+	0x6c, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x1c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x06,
+	0xa2, 0xbb, 0x18, 0x02, 0x08, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x18, 0x0a,
+	0x16, 0x53, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x8b, 0x02, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61,
+	0x51, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x3a, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x12, 0x15, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x15,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x42, 0x0a, 0x0d, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x15, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e,
+	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x40, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x12, 0x17, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x82, 0x03, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x51, 0x48,
+	0x6f, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x15,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3a, 0x0a, 0x07, 0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x15, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x71, 0x2e, 0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x12, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x4f, 0x77, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f,
+	0x77, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x4f, 0x77, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x71, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x71,
+	0x2e, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x53, 0x65, 0x74,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x71, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x67, 0x6f,
+	0x2e, 0x71, 0x75, 0x69, 0x6e, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2f,
+	0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -969,53 +2162,119 @@ func file_rpc_dataq_proto_rawDescGZIP() []byte {
 	return file_rpc_dataq_proto_rawDescData
 }
 
-var file_rpc_dataq_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_rpc_dataq_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rpc_dataq_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_rpc_dataq_proto_goTypes = []any{
-	(*InstallRequest)(nil),              // 0: dataq.InstallRequest
-	(*InstallResponse)(nil),             // 1: dataq.InstallResponse
-	(*PluginConfig)(nil),                // 2: dataq.PluginConfig
-	(*ExtractRequest)(nil),              // 3: dataq.ExtractRequest
-	(*ExtractResponse)(nil),             // 4: dataq.ExtractResponse
-	(*TransformRequest)(nil),            // 5: dataq.TransformRequest
-	(*TransformResponse)(nil),           // 6: dataq.TransformResponse
-	(*InstallResponse_Extract)(nil),     // 7: dataq.InstallResponse.Extract
-	nil,                                 // 8: dataq.ExtractRequest.MetadataEntry
-	(*ExtractResponse_Transform)(nil),   // 9: dataq.ExtractResponse.Transform
-	nil,                                 // 10: dataq.ExtractResponse.Transform.MetadataEntry
-	nil,                                 // 11: dataq.TransformRequest.MetadataEntry
-	(*TransformResponse_Extract)(nil),   // 12: dataq.TransformResponse.Extract
-	(*TransformResponse_Permanode)(nil), // 13: dataq.TransformResponse.Permanode
-	nil,                                 // 14: dataq.TransformResponse.Extract.MetadataEntry
-	(*OAuth2)(nil),                      // 15: dataq.OAuth2
-	(*Email)(nil),                       // 16: dataq.Email
-	(*FinancialTransaction)(nil),        // 17: dataq.FinancialTransaction
+	(Freshness_Policy)(0),               // 0: dataq.Freshness.Policy
+	(*InstallRequest)(nil),              // 1: dataq.InstallRequest
+	(*Freshness)(nil),                   // 2: dataq.Freshness
+	(*InstallResponse)(nil),             // 3: dataq.InstallResponse
+	(*PluginConfig)(nil),                // 4: dataq.PluginConfig
+	(*ExtractRequest)(nil),              // 5: dataq.ExtractRequest
+	(*ExtractResponse)(nil),             // 6: dataq.ExtractResponse
+	(*TransformRequest)(nil),            // 7: dataq.TransformRequest
+	(*TransformResponse)(nil),           // 8: dataq.TransformResponse
+	(*GetBlobRequest)(nil),              // 9: dataq.GetBlobRequest
+	(*GetBlobResponse)(nil),             // 10: dataq.GetBlobResponse
+	(*PutBlobRequest)(nil),              // 11: dataq.PutBlobRequest
+	(*PutBlobResponse)(nil),             // 12: dataq.PutBlobResponse
+	(*QueryOwnPermanodesRequest)(nil),   // 13: dataq.QueryOwnPermanodesRequest
+	(*QueryOwnPermanodesResponse)(nil),  // 14: dataq.QueryOwnPermanodesResponse
+	(*GetPluginStateRequest)(nil),       // 15: dataq.GetPluginStateRequest
+	(*GetPluginStateResponse)(nil),      // 16: dataq.GetPluginStateResponse
+	(*SetPluginStateRequest)(nil),       // 17: dataq.SetPluginStateRequest
+	(*SetPluginStateResponse)(nil),      // 18: dataq.SetPluginStateResponse
+	(*InstallResponse_Extract)(nil),     // 19: dataq.InstallResponse.Extract
+	(*InstallResponse_Transform)(nil),   // 20: dataq.InstallResponse.Transform
+	nil,                                 // 21: dataq.ExtractRequest.MetadataEntry
+	nil,                                 // 22: dataq.ExtractRequest.StateEntry
+	(*ExtractResponse_Transform)(nil),   // 23: dataq.ExtractResponse.Transform
+	nil,                                 // 24: dataq.ExtractResponse.StateEntry
+	nil,                                 // 25: dataq.ExtractResponse.Transform.MetadataEntry
+	nil,                                 // 26: dataq.TransformRequest.MetadataEntry
+	(*TransformResponse_Extract)(nil),   // 27: dataq.TransformResponse.Extract
+	(*TransformResponse_Permanode)(nil), // 28: dataq.TransformResponse.Permanode
+	nil,                                 // 29: dataq.TransformResponse.Extract.MetadataEntry
+	nil,                                 // 30: dataq.QueryOwnPermanodesRequest.FiltersEntry
+	(*QueryOwnPermanodesResponse_Permanode)(nil), // 31: dataq.QueryOwnPermanodesResponse.Permanode
+	(*durationpb.Duration)(nil),                  // 32: google.protobuf.Duration
+	(*OAuth2)(nil),                               // 33: dataq.OAuth2
+	(*descriptorpb.FileDescriptorSet)(nil),       // 34: google.protobuf.FileDescriptorSet
+	(*timestamppb.Timestamp)(nil),                // 35: google.protobuf.Timestamp
+	(*Email)(nil),                                // 36: dataq.Email
+	(*FinancialTransaction)(nil),                 // 37: dataq.FinancialTransaction
+	(*Contact)(nil),                              // 38: dataq.Contact
+	(*CalendarEvent)(nil),                        // 39: dataq.CalendarEvent
+	(*MediaItem)(nil),                            // 40: dataq.MediaItem
+	(*LocationPoint)(nil),                        // 41: dataq.LocationPoint
+	(*Document)(nil),                             // 42: dataq.Document
+	(*HealthMetric)(nil),                         // 43: dataq.HealthMetric
+	(*ChatMessage)(nil),                          // 44: dataq.ChatMessage
+	(*Bookmark)(nil),                             // 45: dataq.Bookmark
+	(*Attachment)(nil),                           // 46: dataq.Attachment
+	(*anypb.Any)(nil),                            // 47: google.protobuf.Any
 }
 var file_rpc_dataq_proto_depIdxs = []int32{
-	2,  // 0: dataq.InstallResponse.configs:type_name -> dataq.PluginConfig
-	15, // 1: dataq.InstallResponse.oauth:type_name -> dataq.OAuth2
-	7,  // 2: dataq.InstallResponse.extracts:type_name -> dataq.InstallResponse.Extract
-	15, // 3: dataq.ExtractRequest.oauth:type_name -> dataq.OAuth2
-	8,  // 4: dataq.ExtractRequest.metadata:type_name -> dataq.ExtractRequest.MetadataEntry
-	9,  // 5: dataq.ExtractResponse.transforms:type_name -> dataq.ExtractResponse.Transform
-	11, // 6: dataq.TransformRequest.metadata:type_name -> dataq.TransformRequest.MetadataEntry
-	12, // 7: dataq.TransformResponse.extracts:type_name -> dataq.TransformResponse.Extract
-	13, // 8: dataq.TransformResponse.permanodes:type_name -> dataq.TransformResponse.Permanode
-	2,  // 9: dataq.InstallResponse.Extract.configs:type_name -> dataq.PluginConfig
-	10, // 10: dataq.ExtractResponse.Transform.metadata:type_name -> dataq.ExtractResponse.Transform.MetadataEntry
-	14, // 11: dataq.TransformResponse.Extract.metadata:type_name -> dataq.TransformResponse.Extract.MetadataEntry
-	16, // 12: dataq.TransformResponse.Permanode.email:type_name -> dataq.Email
-	17, // 13: dataq.TransformResponse.Permanode.financial_transaction:type_name -> dataq.FinancialTransaction
-	0,  // 14: dataq.DataQPlugin.Install:input_type -> dataq.InstallRequest
-	3,  // 15: dataq.DataQPlugin.Extract:input_type -> dataq.ExtractRequest
-	5,  // 16: dataq.DataQPlugin.Transform:input_type -> dataq.TransformRequest
-	1,  // 17: dataq.DataQPlugin.Install:output_type -> dataq.InstallResponse
-	4,  // 18: dataq.DataQPlugin.Extract:output_type -> dataq.ExtractResponse
-	6,  // 19: dataq.DataQPlugin.Transform:output_type -> dataq.TransformResponse
-	17, // [17:20] is the sub-list for method output_type
-	14, // [14:17] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	0,  // 0: dataq.Freshness.policy:type_name -> dataq.Freshness.Policy
+	32, // 1: dataq.Freshness.ttl:type_name -> google.protobuf.Duration
+	4,  // 2: dataq.InstallResponse.configs:type_name -> dataq.PluginConfig
+	33, // 3: dataq.InstallResponse.oauth:type_name -> dataq.OAuth2
+	19, // 4: dataq.InstallResponse.extracts:type_name -> dataq.InstallResponse.Extract
+	20, // 5: dataq.InstallResponse.transforms:type_name -> dataq.InstallResponse.Transform
+	34, // 6: dataq.InstallResponse.descriptors:type_name -> google.protobuf.FileDescriptorSet
+	33, // 7: dataq.ExtractRequest.oauth:type_name -> dataq.OAuth2
+	21, // 8: dataq.ExtractRequest.metadata:type_name -> dataq.ExtractRequest.MetadataEntry
+	22, // 9: dataq.ExtractRequest.state:type_name -> dataq.ExtractRequest.StateEntry
+	23, // 10: dataq.ExtractResponse.transforms:type_name -> dataq.ExtractResponse.Transform
+	35, // 11: dataq.ExtractResponse.received_at:type_name -> google.protobuf.Timestamp
+	24, // 12: dataq.ExtractResponse.state:type_name -> dataq.ExtractResponse.StateEntry
+	26, // 13: dataq.TransformRequest.metadata:type_name -> dataq.TransformRequest.MetadataEntry
+	27, // 14: dataq.TransformResponse.extracts:type_name -> dataq.TransformResponse.Extract
+	28, // 15: dataq.TransformResponse.permanodes:type_name -> dataq.TransformResponse.Permanode
+	35, // 16: dataq.TransformResponse.received_at:type_name -> google.protobuf.Timestamp
+	30, // 17: dataq.QueryOwnPermanodesRequest.filters:type_name -> dataq.QueryOwnPermanodesRequest.FiltersEntry
+	31, // 18: dataq.QueryOwnPermanodesResponse.permanodes:type_name -> dataq.QueryOwnPermanodesResponse.Permanode
+	4,  // 19: dataq.InstallResponse.Extract.configs:type_name -> dataq.PluginConfig
+	2,  // 20: dataq.InstallResponse.Extract.freshness:type_name -> dataq.Freshness
+	2,  // 21: dataq.InstallResponse.Transform.freshness:type_name -> dataq.Freshness
+	25, // 22: dataq.ExtractResponse.Transform.metadata:type_name -> dataq.ExtractResponse.Transform.MetadataEntry
+	29, // 23: dataq.TransformResponse.Extract.metadata:type_name -> dataq.TransformResponse.Extract.MetadataEntry
+	36, // 24: dataq.TransformResponse.Permanode.email:type_name -> dataq.Email
+	37, // 25: dataq.TransformResponse.Permanode.financial_transaction:type_name -> dataq.FinancialTransaction
+	38, // 26: dataq.TransformResponse.Permanode.contact:type_name -> dataq.Contact
+	39, // 27: dataq.TransformResponse.Permanode.calendar_event:type_name -> dataq.CalendarEvent
+	40, // 28: dataq.TransformResponse.Permanode.media_item:type_name -> dataq.MediaItem
+	41, // 29: dataq.TransformResponse.Permanode.location_point:type_name -> dataq.LocationPoint
+	42, // 30: dataq.TransformResponse.Permanode.document:type_name -> dataq.Document
+	43, // 31: dataq.TransformResponse.Permanode.health_metric:type_name -> dataq.HealthMetric
+	44, // 32: dataq.TransformResponse.Permanode.chat_message:type_name -> dataq.ChatMessage
+	45, // 33: dataq.TransformResponse.Permanode.bookmark:type_name -> dataq.Bookmark
+	46, // 34: dataq.TransformResponse.Permanode.attachment:type_name -> dataq.Attachment
+	47, // 35: dataq.TransformResponse.Permanode.any:type_name -> google.protobuf.Any
+	35, // 36: dataq.QueryOwnPermanodesResponse.Permanode.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 37: dataq.DataQPlugin.Install:input_type -> dataq.InstallRequest
+	5,  // 38: dataq.DataQPlugin.Extract:input_type -> dataq.ExtractRequest
+	5,  // 39: dataq.DataQPlugin.ExtractStream:input_type -> dataq.ExtractRequest
+	7,  // 40: dataq.DataQPlugin.Transform:input_type -> dataq.TransformRequest
+	9,  // 41: dataq.DataQHost.GetBlob:input_type -> dataq.GetBlobRequest
+	11, // 42: dataq.DataQHost.PutBlob:input_type -> dataq.PutBlobRequest
+	13, // 43: dataq.DataQHost.QueryOwnPermanodes:input_type -> dataq.QueryOwnPermanodesRequest
+	15, // 44: dataq.DataQHost.GetPluginState:input_type -> dataq.GetPluginStateRequest
+	17, // 45: dataq.DataQHost.SetPluginState:input_type -> dataq.SetPluginStateRequest
+	3,  // 46: dataq.DataQPlugin.Install:output_type -> dataq.InstallResponse
+	6,  // 47: dataq.DataQPlugin.Extract:output_type -> dataq.ExtractResponse
+	6,  // 48: dataq.DataQPlugin.ExtractStream:output_type -> dataq.ExtractResponse
+	8,  // 49: dataq.DataQPlugin.Transform:output_type -> dataq.TransformResponse
+	10, // 50: dataq.DataQHost.GetBlob:output_type -> dataq.GetBlobResponse
+	12, // 51: dataq.DataQHost.PutBlob:output_type -> dataq.PutBlobResponse
+	14, // 52: dataq.DataQHost.QueryOwnPermanodes:output_type -> dataq.QueryOwnPermanodesResponse
+	16, // 53: dataq.DataQHost.GetPluginState:output_type -> dataq.GetPluginStateResponse
+	18, // 54: dataq.DataQHost.SetPluginState:output_type -> dataq.SetPluginStateResponse
+	46, // [46:55] is the sub-list for method output_type
+	37, // [37:46] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_rpc_dataq_proto_init() }
@@ -1025,30 +2284,42 @@ func file_rpc_dataq_proto_init() {
 	}
 	file_rpc_schema_proto_init()
 	file_rpc_oauth2_proto_init()
-	file_rpc_dataq_proto_msgTypes[4].OneofWrappers = []any{
+	file_rpc_options_proto_init()
+	file_rpc_dataq_proto_msgTypes[5].OneofWrappers = []any{
 		(*ExtractResponse_Hash)(nil),
 		(*ExtractResponse_Content)(nil),
 	}
-	file_rpc_dataq_proto_msgTypes[5].OneofWrappers = []any{
+	file_rpc_dataq_proto_msgTypes[6].OneofWrappers = []any{
 		(*TransformRequest_Hash)(nil),
 		(*TransformRequest_Content)(nil),
 	}
-	file_rpc_dataq_proto_msgTypes[13].OneofWrappers = []any{
+	file_rpc_dataq_proto_msgTypes[27].OneofWrappers = []any{
 		(*TransformResponse_Permanode_Email)(nil),
 		(*TransformResponse_Permanode_FinancialTransaction)(nil),
+		(*TransformResponse_Permanode_Contact)(nil),
+		(*TransformResponse_Permanode_CalendarEvent)(nil),
+		(*TransformResponse_Permanode_MediaItem)(nil),
+		(*TransformResponse_Permanode_LocationPoint)(nil),
+		(*TransformResponse_Permanode_Document)(nil),
+		(*TransformResponse_Permanode_HealthMetric)(nil),
+		(*TransformResponse_Permanode_ChatMessage)(nil),
+		(*TransformResponse_Permanode_Bookmark)(nil),
+		(*TransformResponse_Permanode_Attachment)(nil),
+		(*TransformResponse_Permanode_Any)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_dataq_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   15,
+			NumEnums:      1,
+			NumMessages:   31,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_rpc_dataq_proto_goTypes,
 		DependencyIndexes: file_rpc_dataq_proto_depIdxs,
+		EnumInfos:         file_rpc_dataq_proto_enumTypes,
 		MessageInfos:      file_rpc_dataq_proto_msgTypes,
 	}.Build()
 	File_rpc_dataq_proto = out.File
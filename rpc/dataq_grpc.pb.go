@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DataQPlugin_Install_FullMethodName   = "/dataq.DataQPlugin/Install"
-	DataQPlugin_Extract_FullMethodName   = "/dataq.DataQPlugin/Extract"
-	DataQPlugin_Transform_FullMethodName = "/dataq.DataQPlugin/Transform"
+	DataQPlugin_Install_FullMethodName       = "/dataq.DataQPlugin/Install"
+	DataQPlugin_Extract_FullMethodName       = "/dataq.DataQPlugin/Extract"
+	DataQPlugin_ExtractStream_FullMethodName = "/dataq.DataQPlugin/ExtractStream"
+	DataQPlugin_Transform_FullMethodName     = "/dataq.DataQPlugin/Transform"
 )
 
 // DataQPluginClient is the client API for DataQPlugin service.
@@ -33,6 +34,10 @@ type DataQPluginClient interface {
 	Install(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (*InstallResponse, error)
 	// Handle extract requests
 	Extract(ctx context.Context, in *ExtractRequest, opts ...grpc.CallOption) (*ExtractResponse, error)
+	// Handle extract requests of kinds declared with stream, which produce
+	// many items. Each item is a response with its own kind, content and
+	// transforms. The host stores every item before it receives the next one.
+	ExtractStream(ctx context.Context, in *ExtractRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExtractResponse], error)
 	// Handle transform requests
 	Transform(ctx context.Context, in *TransformRequest, opts ...grpc.CallOption) (*TransformResponse, error)
 }
@@ -65,6 +70,25 @@ func (c *dataQPluginClient) Extract(ctx context.Context, in *ExtractRequest, opt
 	return out, nil
 }
 
+func (c *dataQPluginClient) ExtractStream(ctx context.Context, in *ExtractRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExtractResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataQPlugin_ServiceDesc.Streams[0], DataQPlugin_ExtractStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExtractRequest, ExtractResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataQPlugin_ExtractStreamClient = grpc.ServerStreamingClient[ExtractResponse]
+
 func (c *dataQPluginClient) Transform(ctx context.Context, in *TransformRequest, opts ...grpc.CallOption) (*TransformResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TransformResponse)
@@ -84,6 +108,10 @@ type DataQPluginServer interface {
 	Install(context.Context, *InstallRequest) (*InstallResponse, error)
 	// Handle extract requests
 	Extract(context.Context, *ExtractRequest) (*ExtractResponse, error)
+	// Handle extract requests of kinds declared with stream, which produce
+	// many items. Each item is a response with its own kind, content and
+	// transforms. The host stores every item before it receives the next one.
+	ExtractStream(*ExtractRequest, grpc.ServerStreamingServer[ExtractResponse]) error
 	// Handle transform requests
 	Transform(context.Context, *TransformRequest) (*TransformResponse, error)
 	mustEmbedUnimplementedDataQPluginServer()
@@ -102,6 +130,9 @@ func (UnimplementedDataQPluginServer) Install(context.Context, *InstallRequest)
 func (UnimplementedDataQPluginServer) Extract(context.Context, *ExtractRequest) (*ExtractResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Extract not implemented")
 }
+func (UnimplementedDataQPluginServer) ExtractStream(*ExtractRequest, grpc.ServerStreamingServer[ExtractResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExtractStream not implemented")
+}
 func (UnimplementedDataQPluginServer) Transform(context.Context, *TransformRequest) (*TransformResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Transform not implemented")
 }
@@ -162,6 +193,17 @@ func _DataQPlugin_Extract_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataQPlugin_ExtractStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExtractRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataQPluginServer).ExtractStream(m, &grpc.GenericServerStream[ExtractRequest, ExtractResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataQPlugin_ExtractStreamServer = grpc.ServerStreamingServer[ExtractResponse]
+
 func _DataQPlugin_Transform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TransformRequest)
 	if err := dec(in); err != nil {
@@ -200,6 +242,286 @@ var DataQPlugin_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _DataQPlugin_Transform_Handler,
 		},
 	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExtractStream",
+			Handler:       _DataQPlugin_ExtractStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/dataq.proto",
+}
+
+const (
+	DataQHost_GetBlob_FullMethodName            = "/dataq.DataQHost/GetBlob"
+	DataQHost_PutBlob_FullMethodName            = "/dataq.DataQHost/PutBlob"
+	DataQHost_QueryOwnPermanodes_FullMethodName = "/dataq.DataQHost/QueryOwnPermanodes"
+	DataQHost_GetPluginState_FullMethodName     = "/dataq.DataQHost/GetPluginState"
+	DataQHost_SetPluginState_FullMethodName     = "/dataq.DataQHost/SetPluginState"
+)
+
+// DataQHostClient is the client API for DataQHost service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service the host exposes to each plugin it starts, at the address in the
+// plugin's environment. Every request names the plugin instance it is made for,
+// and the host only allows a plugin the data of its own instances.
+type DataQHostClient interface {
+	// Read a blob the instance extracted, stored with PutBlob, or that is the
+	// content of one of its permanodes
+	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobResponse, error)
+	// Store a blob in the CAS, readable by the instance with GetBlob
+	PutBlob(ctx context.Context, in *PutBlobRequest, opts ...grpc.CallOption) (*PutBlobResponse, error)
+	// Query the latest content of the permanodes the instance manages
+	QueryOwnPermanodes(ctx context.Context, in *QueryOwnPermanodesRequest, opts ...grpc.CallOption) (*QueryOwnPermanodesResponse, error)
+	// Read and write a key of the instance's sync state, the state extract
+	// requests carry. An empty value removes the key.
+	GetPluginState(ctx context.Context, in *GetPluginStateRequest, opts ...grpc.CallOption) (*GetPluginStateResponse, error)
+	SetPluginState(ctx context.Context, in *SetPluginStateRequest, opts ...grpc.CallOption) (*SetPluginStateResponse, error)
+}
+
+type dataQHostClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataQHostClient(cc grpc.ClientConnInterface) DataQHostClient {
+	return &dataQHostClient{cc}
+}
+
+func (c *dataQHostClient) GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlobResponse)
+	err := c.cc.Invoke(ctx, DataQHost_GetBlob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataQHostClient) PutBlob(ctx context.Context, in *PutBlobRequest, opts ...grpc.CallOption) (*PutBlobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutBlobResponse)
+	err := c.cc.Invoke(ctx, DataQHost_PutBlob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataQHostClient) QueryOwnPermanodes(ctx context.Context, in *QueryOwnPermanodesRequest, opts ...grpc.CallOption) (*QueryOwnPermanodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryOwnPermanodesResponse)
+	err := c.cc.Invoke(ctx, DataQHost_QueryOwnPermanodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataQHostClient) GetPluginState(ctx context.Context, in *GetPluginStateRequest, opts ...grpc.CallOption) (*GetPluginStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPluginStateResponse)
+	err := c.cc.Invoke(ctx, DataQHost_GetPluginState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataQHostClient) SetPluginState(ctx context.Context, in *SetPluginStateRequest, opts ...grpc.CallOption) (*SetPluginStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPluginStateResponse)
+	err := c.cc.Invoke(ctx, DataQHost_SetPluginState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DataQHostServer is the server API for DataQHost service.
+// All implementations must embed UnimplementedDataQHostServer
+// for forward compatibility.
+//
+// Service the host exposes to each plugin it starts, at the address in the
+// plugin's environment. Every request names the plugin instance it is made for,
+// and the host only allows a plugin the data of its own instances.
+type DataQHostServer interface {
+	// Read a blob the instance extracted, stored with PutBlob, or that is the
+	// content of one of its permanodes
+	GetBlob(context.Context, *GetBlobRequest) (*GetBlobResponse, error)
+	// Store a blob in the CAS, readable by the instance with GetBlob
+	PutBlob(context.Context, *PutBlobRequest) (*PutBlobResponse, error)
+	// Query the latest content of the permanodes the instance manages
+	QueryOwnPermanodes(context.Context, *QueryOwnPermanodesRequest) (*QueryOwnPermanodesResponse, error)
+	// Read and write a key of the instance's sync state, the state extract
+	// requests carry. An empty value removes the key.
+	GetPluginState(context.Context, *GetPluginStateRequest) (*GetPluginStateResponse, error)
+	SetPluginState(context.Context, *SetPluginStateRequest) (*SetPluginStateResponse, error)
+	mustEmbedUnimplementedDataQHostServer()
+}
+
+// UnimplementedDataQHostServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDataQHostServer struct{}
+
+func (UnimplementedDataQHostServer) GetBlob(context.Context, *GetBlobRequest) (*GetBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlob not implemented")
+}
+func (UnimplementedDataQHostServer) PutBlob(context.Context, *PutBlobRequest) (*PutBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutBlob not implemented")
+}
+func (UnimplementedDataQHostServer) QueryOwnPermanodes(context.Context, *QueryOwnPermanodesRequest) (*QueryOwnPermanodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryOwnPermanodes not implemented")
+}
+func (UnimplementedDataQHostServer) GetPluginState(context.Context, *GetPluginStateRequest) (*GetPluginStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPluginState not implemented")
+}
+func (UnimplementedDataQHostServer) SetPluginState(context.Context, *SetPluginStateRequest) (*SetPluginStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPluginState not implemented")
+}
+func (UnimplementedDataQHostServer) mustEmbedUnimplementedDataQHostServer() {}
+func (UnimplementedDataQHostServer) testEmbeddedByValue()                   {}
+
+// UnsafeDataQHostServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataQHostServer will
+// result in compilation errors.
+type UnsafeDataQHostServer interface {
+	mustEmbedUnimplementedDataQHostServer()
+}
+
+func RegisterDataQHostServer(s grpc.ServiceRegistrar, srv DataQHostServer) {
+	// If the following call pancis, it indicates UnimplementedDataQHostServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DataQHost_ServiceDesc, srv)
+}
+
+func _DataQHost_GetBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataQHostServer).GetBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataQHost_GetBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataQHostServer).GetBlob(ctx, req.(*GetBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataQHost_PutBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataQHostServer).PutBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataQHost_PutBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataQHostServer).PutBlob(ctx, req.(*PutBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataQHost_QueryOwnPermanodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryOwnPermanodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataQHostServer).QueryOwnPermanodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataQHost_QueryOwnPermanodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataQHostServer).QueryOwnPermanodes(ctx, req.(*QueryOwnPermanodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataQHost_GetPluginState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPluginStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataQHostServer).GetPluginState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataQHost_GetPluginState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataQHostServer).GetPluginState(ctx, req.(*GetPluginStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataQHost_SetPluginState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPluginStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataQHostServer).SetPluginState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataQHost_SetPluginState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataQHostServer).SetPluginState(ctx, req.(*SetPluginStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DataQHost_ServiceDesc is the grpc.ServiceDesc for DataQHost service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataQHost_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dataq.DataQHost",
+	HandlerType: (*DataQHostServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlob",
+			Handler:    _DataQHost_GetBlob_Handler,
+		},
+		{
+			MethodName: "PutBlob",
+			Handler:    _DataQHost_PutBlob_Handler,
+		},
+		{
+			MethodName: "QueryOwnPermanodes",
+			Handler:    _DataQHost_QueryOwnPermanodes_Handler,
+		},
+		{
+			MethodName: "GetPluginState",
+			Handler:    _DataQHost_GetPluginState_Handler,
+		},
+		{
+			MethodName: "SetPluginState",
+			Handler:    _DataQHost_SetPluginState_Handler,
+		},
+	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/dataq.proto",
 }
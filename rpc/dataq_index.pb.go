@@ -2,10 +2,19 @@
 
 package rpc
 
+import (
+	validate "go.quinn.io/dataq/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+)
+
 func (m *InstallRequest) SchemaKind() string {
 	return "InstallRequest"
 }
 
+func (m *InstallRequest) SchemaVersion() int {
+	return 1
+}
+
 func (m *InstallRequest) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
@@ -15,10 +24,57 @@ func (m *InstallRequest) SchemaMetadata() map[string]interface{} {
 	return metadata
 }
 
+func (m *InstallRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *Freshness) SchemaKind() string {
+	return "Freshness"
+}
+
+func (m *Freshness) SchemaVersion() int {
+	return 1
+}
+
+func (m *Freshness) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Policy != 0 {
+		metadata["policy"] = m.Policy
+	}
+	if m.Ttl != nil {
+		if m.Ttl.Seconds != 0 {
+			metadata["ttl.seconds"] = m.Ttl.Seconds
+		}
+		if m.Ttl.Nanos != 0 {
+			metadata["ttl.nanos"] = m.Ttl.Nanos
+		}
+	}
+	return metadata
+}
+
+func (m *Freshness) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
 func (m *InstallResponse) SchemaKind() string {
 	return "InstallResponse"
 }
 
+func (m *InstallResponse) SchemaVersion() int {
+	return 1
+}
+
 func (m *InstallResponse) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
@@ -29,18 +85,88 @@ func (m *InstallResponse) SchemaMetadata() map[string]interface{} {
 		metadata["configs"] = m.Configs
 	}
 	if m.Oauth != nil {
-		metadata["oauth"] = m.Oauth
+		if m.Oauth.Config != nil {
+			if m.Oauth.Config.ClientId != "" {
+				metadata["oauth.config.client_id"] = m.Oauth.Config.ClientId
+			}
+			if m.Oauth.Config.ClientSecret != "" {
+				metadata["oauth.config.client_secret"] = m.Oauth.Config.ClientSecret
+			}
+			if m.Oauth.Config.Endpoint != nil {
+				if m.Oauth.Config.Endpoint.AuthUrl != "" {
+					metadata["oauth.config.endpoint.auth_url"] = m.Oauth.Config.Endpoint.AuthUrl
+				}
+				if m.Oauth.Config.Endpoint.DeviceAuthUrl != "" {
+					metadata["oauth.config.endpoint.device_auth_url"] = m.Oauth.Config.Endpoint.DeviceAuthUrl
+				}
+				if m.Oauth.Config.Endpoint.TokenUrl != "" {
+					metadata["oauth.config.endpoint.token_url"] = m.Oauth.Config.Endpoint.TokenUrl
+				}
+				if m.Oauth.Config.Endpoint.AuthStyle != 0 {
+					metadata["oauth.config.endpoint.auth_style"] = m.Oauth.Config.Endpoint.AuthStyle
+				}
+			}
+			if m.Oauth.Config.RedirectUrl != "" {
+				metadata["oauth.config.redirect_url"] = m.Oauth.Config.RedirectUrl
+			}
+			if len(m.Oauth.Config.Scopes) > 0 {
+				metadata["oauth.config.scopes"] = m.Oauth.Config.Scopes
+			}
+		}
+		if m.Oauth.Token != nil {
+			if m.Oauth.Token.AccessToken != "" {
+				metadata["oauth.token.access_token"] = m.Oauth.Token.AccessToken
+			}
+			if m.Oauth.Token.TokenType != "" {
+				metadata["oauth.token.token_type"] = m.Oauth.Token.TokenType
+			}
+			if m.Oauth.Token.RefreshToken != "" {
+				metadata["oauth.token.refresh_token"] = m.Oauth.Token.RefreshToken
+			}
+			if m.Oauth.Token.Expiry != 0 {
+				metadata["oauth.token.expiry"] = m.Oauth.Token.Expiry
+			}
+			if m.Oauth.Token.ExpiresIn != 0 {
+				metadata["oauth.token.expires_in"] = m.Oauth.Token.ExpiresIn
+			}
+		}
 	}
 	if len(m.Extracts) > 0 {
 		metadata["extracts"] = m.Extracts
 	}
+	if len(m.Transforms) > 0 {
+		metadata["transforms"] = m.Transforms
+	}
 	return metadata
 }
 
+func (m *InstallResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	for n, v := range m.GetConfigs() {
+		errs.Add(validate.Index("configs", n), validate.Message(v))
+	}
+	errs.Add("oauth", validate.Message(m.GetOauth()))
+	for n, v := range m.GetExtracts() {
+		errs.Add(validate.Index("extracts", n), validate.Message(v))
+	}
+	for n, v := range m.GetTransforms() {
+		errs.Add(validate.Index("transforms", n), validate.Message(v))
+	}
+	return errs.Err()
+}
+
 func (m *PluginConfig) SchemaKind() string {
 	return "PluginConfig"
 }
 
+func (m *PluginConfig) SchemaVersion() int {
+	return 1
+}
+
 func (m *PluginConfig) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
@@ -53,18 +179,28 @@ func (m *PluginConfig) SchemaMetadata() map[string]interface{} {
 	return metadata
 }
 
+func (m *PluginConfig) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
 func (m *ExtractRequest) SchemaKind() string {
 	return "ExtractRequest"
 }
 
+func (m *ExtractRequest) SchemaVersion() int {
+	return 2
+}
+
 func (m *ExtractRequest) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
-	if m.Oauth != nil {
-		metadata["oauth"] = m.Oauth
-	}
-	if m.PluginId != "" {
-		metadata["plugin_id"] = m.PluginId
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
 	}
 	if m.ParentHash != "" {
 		metadata["parent_hash"] = m.ParentHash
@@ -78,10 +214,24 @@ func (m *ExtractRequest) SchemaMetadata() map[string]interface{} {
 	return metadata
 }
 
+func (m *ExtractRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	errs.Add("oauth", validate.Message(m.GetOauth()))
+	return errs.Err()
+}
+
 func (m *ExtractResponse) SchemaKind() string {
 	return "ExtractResponse"
 }
 
+func (m *ExtractResponse) SchemaVersion() int {
+	return 1
+}
+
 func (m *ExtractResponse) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
@@ -94,26 +244,43 @@ func (m *ExtractResponse) SchemaMetadata() map[string]interface{} {
 	if len(m.Transforms) > 0 {
 		metadata["transforms"] = m.Transforms
 	}
+	if m.ReceivedAt != nil {
+		metadata["received_at"] = m.ReceivedAt.AsTime().UnixMilli()
+	}
 	if m.Data != nil {
 		switch {
 		case m.GetHash() != "":
 			metadata["data_hash"] = m.GetHash()
-		case m.GetContent() != nil:
-			metadata["data_content"] = m.GetContent()
 		}
 	}
 	return metadata
 }
 
+func (m *ExtractResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	for n, v := range m.GetTransforms() {
+		errs.Add(validate.Index("transforms", n), validate.Message(v))
+	}
+	return errs.Err()
+}
+
 func (m *TransformRequest) SchemaKind() string {
 	return "TransformRequest"
 }
 
+func (m *TransformRequest) SchemaVersion() int {
+	return 2
+}
+
 func (m *TransformRequest) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
-	if m.PluginId != "" {
-		metadata["plugin_id"] = m.PluginId
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
 	}
 	if m.Kind != "" {
 		metadata["kind"] = m.Kind
@@ -125,17 +292,28 @@ func (m *TransformRequest) SchemaMetadata() map[string]interface{} {
 		switch {
 		case m.GetHash() != "":
 			metadata["data_hash"] = m.GetHash()
-		case m.GetContent() != nil:
-			metadata["data_content"] = m.GetContent()
 		}
 	}
 	return metadata
 }
 
+func (m *TransformRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
 func (m *TransformResponse) SchemaKind() string {
 	return "TransformResponse"
 }
 
+func (m *TransformResponse) SchemaVersion() int {
+	return 1
+}
+
 func (m *TransformResponse) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
@@ -148,8 +326,365 @@ func (m *TransformResponse) SchemaMetadata() map[string]interface{} {
 	if len(m.Extracts) > 0 {
 		metadata["extracts"] = m.Extracts
 	}
+	if m.ReceivedAt != nil {
+		metadata["received_at"] = m.ReceivedAt.AsTime().UnixMilli()
+	}
+	return metadata
+}
+
+func (m *TransformResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	for n, v := range m.GetExtracts() {
+		errs.Add(validate.Index("extracts", n), validate.Message(v))
+	}
+	for n, v := range m.GetPermanodes() {
+		errs.Add(validate.Index("permanodes", n), validate.Message(v))
+	}
+	return errs.Err()
+}
+
+func (m *GetBlobRequest) SchemaKind() string {
+	return "GetBlobRequest"
+}
+
+func (m *GetBlobRequest) SchemaVersion() int {
+	return 1
+}
+
+func (m *GetBlobRequest) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
+	}
+	if m.Hash != "" {
+		metadata["hash"] = m.Hash
+	}
+	return metadata
+}
+
+func (m *GetBlobRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *GetBlobResponse) SchemaKind() string {
+	return "GetBlobResponse"
+}
+
+func (m *GetBlobResponse) SchemaVersion() int {
+	return 1
+}
+
+func (m *GetBlobResponse) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Content != nil {
+		metadata["content"] = m.Content
+	}
+	return metadata
+}
+
+func (m *GetBlobResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *PutBlobRequest) SchemaKind() string {
+	return "PutBlobRequest"
+}
+
+func (m *PutBlobRequest) SchemaVersion() int {
+	return 1
+}
+
+func (m *PutBlobRequest) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
+	}
+	return metadata
+}
+
+func (m *PutBlobRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *PutBlobResponse) SchemaKind() string {
+	return "PutBlobResponse"
+}
+
+func (m *PutBlobResponse) SchemaVersion() int {
+	return 1
+}
+
+func (m *PutBlobResponse) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Hash != "" {
+		metadata["hash"] = m.Hash
+	}
+	return metadata
+}
+
+func (m *PutBlobResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *QueryOwnPermanodesRequest) SchemaKind() string {
+	return "QueryOwnPermanodesRequest"
+}
+
+func (m *QueryOwnPermanodesRequest) SchemaVersion() int {
+	return 1
+}
+
+func (m *QueryOwnPermanodesRequest) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
+	}
+	if m.Kind != "" {
+		metadata["kind"] = m.Kind
+	}
+	if m.Key != "" {
+		metadata["key"] = m.Key
+	}
+	if m.Filters != nil {
+		metadata["filters"] = m.Filters
+	}
+	if m.Limit != 0 {
+		metadata["limit"] = m.Limit
+	}
+	return metadata
+}
+
+func (m *QueryOwnPermanodesRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *QueryOwnPermanodesResponse) SchemaKind() string {
+	return "QueryOwnPermanodesResponse"
+}
+
+func (m *QueryOwnPermanodesResponse) SchemaVersion() int {
+	return 1
+}
+
+func (m *QueryOwnPermanodesResponse) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
 	if len(m.Permanodes) > 0 {
 		metadata["permanodes"] = m.Permanodes
 	}
 	return metadata
 }
+
+func (m *QueryOwnPermanodesResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	for n, v := range m.GetPermanodes() {
+		errs.Add(validate.Index("permanodes", n), validate.Message(v))
+	}
+	return errs.Err()
+}
+
+func (m *GetPluginStateRequest) SchemaKind() string {
+	return "GetPluginStateRequest"
+}
+
+func (m *GetPluginStateRequest) SchemaVersion() int {
+	return 1
+}
+
+func (m *GetPluginStateRequest) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
+	}
+	if m.Key != "" {
+		metadata["key"] = m.Key
+	}
+	return metadata
+}
+
+func (m *GetPluginStateRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *GetPluginStateResponse) SchemaKind() string {
+	return "GetPluginStateResponse"
+}
+
+func (m *GetPluginStateResponse) SchemaVersion() int {
+	return 1
+}
+
+func (m *GetPluginStateResponse) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Value != nil {
+		metadata["value"] = m.Value
+	}
+	if m.Found != false {
+		metadata["found"] = m.Found
+	}
+	return metadata
+}
+
+func (m *GetPluginStateResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *SetPluginStateRequest) SchemaKind() string {
+	return "SetPluginStateRequest"
+}
+
+func (m *SetPluginStateRequest) SchemaVersion() int {
+	return 1
+}
+
+func (m *SetPluginStateRequest) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.InstanceHash != "" {
+		metadata["instance_hash"] = m.InstanceHash
+	}
+	if m.Key != "" {
+		metadata["key"] = m.Key
+	}
+	return metadata
+}
+
+func (m *SetPluginStateRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *SetPluginStateResponse) SchemaKind() string {
+	return "SetPluginStateResponse"
+}
+
+func (m *SetPluginStateResponse) SchemaVersion() int {
+	return 1
+}
+
+func (m *SetPluginStateResponse) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	return metadata
+}
+
+func (m *SetPluginStateResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+// RegisterDataqKinds registers the kinds declared in rpc/dataq.proto.
+func RegisterDataqKinds(register func(kind string, desc protoreflect.MessageDescriptor, newFn func() protoreflect.ProtoMessage)) {
+	register("InstallRequest", File_rpc_dataq_proto.Messages().ByName("InstallRequest"), func() protoreflect.ProtoMessage {
+		return new(InstallRequest)
+	})
+	register("Freshness", File_rpc_dataq_proto.Messages().ByName("Freshness"), func() protoreflect.ProtoMessage {
+		return new(Freshness)
+	})
+	register("InstallResponse", File_rpc_dataq_proto.Messages().ByName("InstallResponse"), func() protoreflect.ProtoMessage {
+		return new(InstallResponse)
+	})
+	register("PluginConfig", File_rpc_dataq_proto.Messages().ByName("PluginConfig"), func() protoreflect.ProtoMessage {
+		return new(PluginConfig)
+	})
+	register("ExtractRequest", File_rpc_dataq_proto.Messages().ByName("ExtractRequest"), func() protoreflect.ProtoMessage {
+		return new(ExtractRequest)
+	})
+	register("ExtractResponse", File_rpc_dataq_proto.Messages().ByName("ExtractResponse"), func() protoreflect.ProtoMessage {
+		return new(ExtractResponse)
+	})
+	register("TransformRequest", File_rpc_dataq_proto.Messages().ByName("TransformRequest"), func() protoreflect.ProtoMessage {
+		return new(TransformRequest)
+	})
+	register("TransformResponse", File_rpc_dataq_proto.Messages().ByName("TransformResponse"), func() protoreflect.ProtoMessage {
+		return new(TransformResponse)
+	})
+	register("GetBlobRequest", File_rpc_dataq_proto.Messages().ByName("GetBlobRequest"), func() protoreflect.ProtoMessage {
+		return new(GetBlobRequest)
+	})
+	register("GetBlobResponse", File_rpc_dataq_proto.Messages().ByName("GetBlobResponse"), func() protoreflect.ProtoMessage {
+		return new(GetBlobResponse)
+	})
+	register("PutBlobRequest", File_rpc_dataq_proto.Messages().ByName("PutBlobRequest"), func() protoreflect.ProtoMessage {
+		return new(PutBlobRequest)
+	})
+	register("PutBlobResponse", File_rpc_dataq_proto.Messages().ByName("PutBlobResponse"), func() protoreflect.ProtoMessage {
+		return new(PutBlobResponse)
+	})
+	register("QueryOwnPermanodesRequest", File_rpc_dataq_proto.Messages().ByName("QueryOwnPermanodesRequest"), func() protoreflect.ProtoMessage {
+		return new(QueryOwnPermanodesRequest)
+	})
+	register("QueryOwnPermanodesResponse", File_rpc_dataq_proto.Messages().ByName("QueryOwnPermanodesResponse"), func() protoreflect.ProtoMessage {
+		return new(QueryOwnPermanodesResponse)
+	})
+	register("GetPluginStateRequest", File_rpc_dataq_proto.Messages().ByName("GetPluginStateRequest"), func() protoreflect.ProtoMessage {
+		return new(GetPluginStateRequest)
+	})
+	register("GetPluginStateResponse", File_rpc_dataq_proto.Messages().ByName("GetPluginStateResponse"), func() protoreflect.ProtoMessage {
+		return new(GetPluginStateResponse)
+	})
+	register("SetPluginStateRequest", File_rpc_dataq_proto.Messages().ByName("SetPluginStateRequest"), func() protoreflect.ProtoMessage {
+		return new(SetPluginStateRequest)
+	})
+	register("SetPluginStateResponse", File_rpc_dataq_proto.Messages().ByName("SetPluginStateResponse"), func() protoreflect.ProtoMessage {
+		return new(SetPluginStateResponse)
+	})
+}
@@ -2,18 +2,84 @@
 
 package rpc
 
+import (
+	validate "go.quinn.io/dataq/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+)
+
 func (m *OAuth2) SchemaKind() string {
 	return "OAuth2"
 }
 
+func (m *OAuth2) SchemaVersion() int {
+	return 1
+}
+
 func (m *OAuth2) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
 	if m.Config != nil {
-		metadata["config"] = m.Config
+		if m.Config.ClientId != "" {
+			metadata["config.client_id"] = m.Config.ClientId
+		}
+		if m.Config.ClientSecret != "" {
+			metadata["config.client_secret"] = m.Config.ClientSecret
+		}
+		if m.Config.Endpoint != nil {
+			if m.Config.Endpoint.AuthUrl != "" {
+				metadata["config.endpoint.auth_url"] = m.Config.Endpoint.AuthUrl
+			}
+			if m.Config.Endpoint.DeviceAuthUrl != "" {
+				metadata["config.endpoint.device_auth_url"] = m.Config.Endpoint.DeviceAuthUrl
+			}
+			if m.Config.Endpoint.TokenUrl != "" {
+				metadata["config.endpoint.token_url"] = m.Config.Endpoint.TokenUrl
+			}
+			if m.Config.Endpoint.AuthStyle != 0 {
+				metadata["config.endpoint.auth_style"] = m.Config.Endpoint.AuthStyle
+			}
+		}
+		if m.Config.RedirectUrl != "" {
+			metadata["config.redirect_url"] = m.Config.RedirectUrl
+		}
+		if len(m.Config.Scopes) > 0 {
+			metadata["config.scopes"] = m.Config.Scopes
+		}
 	}
 	if m.Token != nil {
-		metadata["token"] = m.Token
+		if m.Token.AccessToken != "" {
+			metadata["token.access_token"] = m.Token.AccessToken
+		}
+		if m.Token.TokenType != "" {
+			metadata["token.token_type"] = m.Token.TokenType
+		}
+		if m.Token.RefreshToken != "" {
+			metadata["token.refresh_token"] = m.Token.RefreshToken
+		}
+		if m.Token.Expiry != 0 {
+			metadata["token.expiry"] = m.Token.Expiry
+		}
+		if m.Token.ExpiresIn != 0 {
+			metadata["token.expires_in"] = m.Token.ExpiresIn
+		}
 	}
 	return metadata
 }
+
+func (m *OAuth2) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	errs.Add("config", validate.Message(m.GetConfig()))
+	errs.Add("token", validate.Message(m.GetToken()))
+	return errs.Err()
+}
+
+// RegisterOauth2Kinds registers the kinds declared in rpc/oauth2.proto.
+func RegisterOauth2Kinds(register func(kind string, desc protoreflect.MessageDescriptor, newFn func() protoreflect.ProtoMessage)) {
+	register("OAuth2", File_rpc_oauth2_proto.Messages().ByName("OAuth2"), func() protoreflect.ProtoMessage {
+		return new(OAuth2)
+	})
+}
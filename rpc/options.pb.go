@@ -0,0 +1,490 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.0
+// 	protoc        v5.29.1
+// source: rpc/options.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IndexOptions_Type int32
+
+const (
+	IndexOptions_DEFAULT IndexOptions_Type = 0
+	IndexOptions_TEXT    IndexOptions_Type = 1
+	// Strings are parsed as numbers
+	IndexOptions_INTEGER IndexOptions_Type = 2
+	IndexOptions_REAL    IndexOptions_Type = 3
+	// Strings are parsed as RFC 3339 times or dates, integers are unix
+	// seconds. Indexed as unix milliseconds, like claim timestamps.
+	IndexOptions_TIMESTAMP IndexOptions_Type = 4
+	// Index a message as a single JSON value instead of flattening it
+	IndexOptions_JSON IndexOptions_Type = 5
+)
+
+// Enum value maps for IndexOptions_Type.
+var (
+	IndexOptions_Type_name = map[int32]string{
+		0: "DEFAULT",
+		1: "TEXT",
+		2: "INTEGER",
+		3: "REAL",
+		4: "TIMESTAMP",
+		5: "JSON",
+	}
+	IndexOptions_Type_value = map[string]int32{
+		"DEFAULT":   0,
+		"TEXT":      1,
+		"INTEGER":   2,
+		"REAL":      3,
+		"TIMESTAMP": 4,
+		"JSON":      5,
+	}
+)
+
+func (x IndexOptions_Type) Enum() *IndexOptions_Type {
+	p := new(IndexOptions_Type)
+	*p = x
+	return p
+}
+
+func (x IndexOptions_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexOptions_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_options_proto_enumTypes[0].Descriptor()
+}
+
+func (IndexOptions_Type) Type() protoreflect.EnumType {
+	return &file_rpc_options_proto_enumTypes[0]
+}
+
+func (x IndexOptions_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexOptions_Type.Descriptor instead.
+func (IndexOptions_Type) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_options_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ValidateOptions_Format int32
+
+const (
+	ValidateOptions_NONE ValidateOptions_Format = 0
+	// A bare address like alice@example.com
+	ValidateOptions_EMAIL ValidateOptions_Format = 1
+	// An RFC 3339 time or a date like 2006-01-02
+	ValidateOptions_DATE ValidateOptions_Format = 2
+	// An absolute URL
+	ValidateOptions_URL ValidateOptions_Format = 3
+)
+
+// Enum value maps for ValidateOptions_Format.
+var (
+	ValidateOptions_Format_name = map[int32]string{
+		0: "NONE",
+		1: "EMAIL",
+		2: "DATE",
+		3: "URL",
+	}
+	ValidateOptions_Format_value = map[string]int32{
+		"NONE":  0,
+		"EMAIL": 1,
+		"DATE":  2,
+		"URL":   3,
+	}
+)
+
+func (x ValidateOptions_Format) Enum() *ValidateOptions_Format {
+	p := new(ValidateOptions_Format)
+	*p = x
+	return p
+}
+
+func (x ValidateOptions_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValidateOptions_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_options_proto_enumTypes[1].Descriptor()
+}
+
+func (ValidateOptions_Format) Type() protoreflect.EnumType {
+	return &file_rpc_options_proto_enumTypes[1]
+}
+
+func (x ValidateOptions_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValidateOptions_Format.Descriptor instead.
+func (ValidateOptions_Format) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_options_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// IndexOptions control how protoc-gen-dataq-index puts a field into the
+// metadata of its message, e.g.
+//
+//	string html = 16 [(dataq.index) = {skip: true}];
+//	string start = 6 [(dataq.index) = {type: TIMESTAMP}];
+//
+// Fields of nested messages are flattened into dotted names like from.email.
+// Repeated and recursive messages are indexed as JSON.
+type IndexOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Leave the field out of the index
+	Skip bool `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	// Match the field with free text search only. The values of all fulltext
+	// fields are joined into a single fulltext column instead of their own.
+	Fulltext bool `protobuf:"varint,2,opt,name=fulltext,proto3" json:"fulltext,omitempty"`
+	// The field identifies the entity, it is used as the permanode key when a
+	// plugin doesn't set one. Several unique_key fields are joined with "/".
+	UniqueKey     bool              `protobuf:"varint,3,opt,name=unique_key,json=uniqueKey,proto3" json:"unique_key,omitempty"`
+	Type          IndexOptions_Type `protobuf:"varint,4,opt,name=type,proto3,enum=dataq.IndexOptions_Type" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexOptions) Reset() {
+	*x = IndexOptions{}
+	mi := &file_rpc_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexOptions) ProtoMessage() {}
+
+func (x *IndexOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexOptions.ProtoReflect.Descriptor instead.
+func (*IndexOptions) Descriptor() ([]byte, []int) {
+	return file_rpc_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IndexOptions) GetSkip() bool {
+	if x != nil {
+		return x.Skip
+	}
+	return false
+}
+
+func (x *IndexOptions) GetFulltext() bool {
+	if x != nil {
+		return x.Fulltext
+	}
+	return false
+}
+
+func (x *IndexOptions) GetUniqueKey() bool {
+	if x != nil {
+		return x.UniqueKey
+	}
+	return false
+}
+
+func (x *IndexOptions) GetType() IndexOptions_Type {
+	if x != nil {
+		return x.Type
+	}
+	return IndexOptions_DEFAULT
+}
+
+// ValidateOptions are checked by the Validate method protoc-gen-dataq-index
+// generates. The host rejects permanode payloads that fail validation.
+//
+//	string start = 6 [(dataq.validate) = {required: true, format: DATE}];
+type ValidateOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The field must be set. Zero values count as unset.
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// Format of a string field, or of each value of a repeated string field.
+	// Empty values are not checked.
+	Format        ValidateOptions_Format `protobuf:"varint,2,opt,name=format,proto3,enum=dataq.ValidateOptions_Format" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateOptions) Reset() {
+	*x = ValidateOptions{}
+	mi := &file_rpc_options_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateOptions) ProtoMessage() {}
+
+func (x *ValidateOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_options_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateOptions.ProtoReflect.Descriptor instead.
+func (*ValidateOptions) Descriptor() ([]byte, []int) {
+	return file_rpc_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidateOptions) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ValidateOptions) GetFormat() ValidateOptions_Format {
+	if x != nil {
+		return x.Format
+	}
+	return ValidateOptions_NONE
+}
+
+// KindOptions describe a message stored as a schema kind, e.g.
+//
+//	message Email {
+//	  option (dataq.kind) = {version: 2};
+//	}
+type KindOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of the schema, stamped on content claims. Bump it when a change
+	// breaks reading stored content and register a migration from the previous
+	// version with index.RegisterMigration. Defaults to 1.
+	Version       int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KindOptions) Reset() {
+	*x = KindOptions{}
+	mi := &file_rpc_options_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KindOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KindOptions) ProtoMessage() {}
+
+func (x *KindOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_options_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KindOptions.ProtoReflect.Descriptor instead.
+func (*KindOptions) Descriptor() ([]byte, []int) {
+	return file_rpc_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KindOptions) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var file_rpc_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*IndexOptions)(nil),
+		Field:         50100,
+		Name:          "dataq.index",
+		Tag:           "bytes,50100,opt,name=index",
+		Filename:      "rpc/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*ValidateOptions)(nil),
+		Field:         50101,
+		Name:          "dataq.validate",
+		Tag:           "bytes,50101,opt,name=validate",
+		Filename:      "rpc/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*KindOptions)(nil),
+		Field:         50102,
+		Name:          "dataq.kind",
+		Tag:           "bytes,50102,opt,name=kind",
+		Filename:      "rpc/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional dataq.IndexOptions index = 50100;
+	E_Index = &file_rpc_options_proto_extTypes[0]
+	// optional dataq.ValidateOptions validate = 50101;
+	E_Validate = &file_rpc_options_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional dataq.KindOptions kind = 50102;
+	E_Kind = &file_rpc_options_proto_extTypes[2]
+)
+
+var File_rpc_options_proto protoreflect.FileDescriptor
+
+var file_rpc_options_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x72, 0x70, 0x63, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x64, 0x61, 0x74, 0x61, 0x71, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xda, 0x01, 0x0a,
+	0x0c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x6b, 0x69,
+	0x70, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x71, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x4d, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12,
+	0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54,
+	0x45, 0x47, 0x45, 0x52, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x4c, 0x10, 0x03,
+	0x12, 0x0d, 0x0a, 0x09, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10, 0x04, 0x12,
+	0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x05, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x71, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x22, 0x30, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f,
+	0x4e, 0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12,
+	0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x52, 0x4c,
+	0x10, 0x03, 0x22, 0x27, 0x0a, 0x0b, 0x4b, 0x69, 0x6e, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x4a, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xb4, 0x87, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x71, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x3a, 0x53, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0xb5, 0x87, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x71, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x49, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb6, 0x87, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x71, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x42, 0x17, 0x5a, 0x15, 0x67, 0x6f, 0x2e, 0x71, 0x75,
+	0x69, 0x6e, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x71, 0x2f, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_options_proto_rawDescOnce sync.Once
+	file_rpc_options_proto_rawDescData = file_rpc_options_proto_rawDesc
+)
+
+func file_rpc_options_proto_rawDescGZIP() []byte {
+	file_rpc_options_proto_rawDescOnce.Do(func() {
+		file_rpc_options_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_options_proto_rawDescData)
+	})
+	return file_rpc_options_proto_rawDescData
+}
+
+var file_rpc_options_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_rpc_options_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rpc_options_proto_goTypes = []any{
+	(IndexOptions_Type)(0),              // 0: dataq.IndexOptions.Type
+	(ValidateOptions_Format)(0),         // 1: dataq.ValidateOptions.Format
+	(*IndexOptions)(nil),                // 2: dataq.IndexOptions
+	(*ValidateOptions)(nil),             // 3: dataq.ValidateOptions
+	(*KindOptions)(nil),                 // 4: dataq.KindOptions
+	(*descriptorpb.FieldOptions)(nil),   // 5: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil), // 6: google.protobuf.MessageOptions
+}
+var file_rpc_options_proto_depIdxs = []int32{
+	0, // 0: dataq.IndexOptions.type:type_name -> dataq.IndexOptions.Type
+	1, // 1: dataq.ValidateOptions.format:type_name -> dataq.ValidateOptions.Format
+	5, // 2: dataq.index:extendee -> google.protobuf.FieldOptions
+	5, // 3: dataq.validate:extendee -> google.protobuf.FieldOptions
+	6, // 4: dataq.kind:extendee -> google.protobuf.MessageOptions
+	2, // 5: dataq.index:type_name -> dataq.IndexOptions
+	3, // 6: dataq.validate:type_name -> dataq.ValidateOptions
+	4, // 7: dataq.kind:type_name -> dataq.KindOptions
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	5, // [5:8] is the sub-list for extension type_name
+	2, // [2:5] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_options_proto_init() }
+func file_rpc_options_proto_init() {
+	if File_rpc_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_options_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 3,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_options_proto_goTypes,
+		DependencyIndexes: file_rpc_options_proto_depIdxs,
+		EnumInfos:         file_rpc_options_proto_enumTypes,
+		MessageInfos:      file_rpc_options_proto_msgTypes,
+		ExtensionInfos:    file_rpc_options_proto_extTypes,
+	}.Build()
+	File_rpc_options_proto = out.File
+	file_rpc_options_proto_rawDesc = nil
+	file_rpc_options_proto_goTypes = nil
+	file_rpc_options_proto_depIdxs = nil
+}
@@ -0,0 +1,111 @@
+// Code generated by protoc-gen-dataq-index. DO NOT EDIT.
+
+package rpc
+
+import (
+	validate "go.quinn.io/dataq/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func (m *IndexOptions) SchemaKind() string {
+	return "IndexOptions"
+}
+
+func (m *IndexOptions) SchemaVersion() int {
+	return 1
+}
+
+func (m *IndexOptions) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Skip != false {
+		metadata["skip"] = m.Skip
+	}
+	if m.Fulltext != false {
+		metadata["fulltext"] = m.Fulltext
+	}
+	if m.UniqueKey != false {
+		metadata["unique_key"] = m.UniqueKey
+	}
+	if m.Type != 0 {
+		metadata["type"] = m.Type
+	}
+	return metadata
+}
+
+func (m *IndexOptions) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *ValidateOptions) SchemaKind() string {
+	return "ValidateOptions"
+}
+
+func (m *ValidateOptions) SchemaVersion() int {
+	return 1
+}
+
+func (m *ValidateOptions) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Required != false {
+		metadata["required"] = m.Required
+	}
+	if m.Format != 0 {
+		metadata["format"] = m.Format
+	}
+	return metadata
+}
+
+func (m *ValidateOptions) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *KindOptions) SchemaKind() string {
+	return "KindOptions"
+}
+
+func (m *KindOptions) SchemaVersion() int {
+	return 1
+}
+
+func (m *KindOptions) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Version != 0 {
+		metadata["version"] = m.Version
+	}
+	return metadata
+}
+
+func (m *KindOptions) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+// RegisterOptionsKinds registers the kinds declared in rpc/options.proto.
+func RegisterOptionsKinds(register func(kind string, desc protoreflect.MessageDescriptor, newFn func() protoreflect.ProtoMessage)) {
+	register("IndexOptions", File_rpc_options_proto.Messages().ByName("IndexOptions"), func() protoreflect.ProtoMessage {
+		return new(IndexOptions)
+	})
+	register("ValidateOptions", File_rpc_options_proto.Messages().ByName("ValidateOptions"), func() protoreflect.ProtoMessage {
+		return new(ValidateOptions)
+	})
+	register("KindOptions", File_rpc_options_proto.Messages().ByName("KindOptions"), func() protoreflect.ProtoMessage {
+		return new(KindOptions)
+	})
+}
@@ -2,28 +2,109 @@
 
 package rpc
 
+import (
+	validate "go.quinn.io/dataq/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	strings "strings"
+	time "time"
+)
+
+func (m *EmailAddress) SchemaKind() string {
+	return "EmailAddress"
+}
+
+func (m *EmailAddress) SchemaVersion() int {
+	return 1
+}
+
+func (m *EmailAddress) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Name != "" {
+		metadata["name"] = m.Name
+	}
+	if m.Email != "" {
+		metadata["email"] = m.Email
+	}
+	return metadata
+}
+
+func (m *EmailAddress) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	errs.Add("email", validate.Email(m.GetEmail()))
+	return errs.Err()
+}
+
+func (m *Attachment) SchemaKind() string {
+	return "Attachment"
+}
+
+func (m *Attachment) SchemaVersion() int {
+	return 1
+}
+
+func (m *Attachment) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.MessageId != "" {
+		metadata["message_id"] = m.MessageId
+	}
+	if m.PartId != "" {
+		metadata["part_id"] = m.PartId
+	}
+	if m.Filename != "" {
+		metadata["filename"] = m.Filename
+	}
+	if m.MimeType != "" {
+		metadata["mime_type"] = m.MimeType
+	}
+	if m.Size != 0 {
+		metadata["size"] = m.Size
+	}
+	if m.DataHash != "" {
+		metadata["data_hash"] = m.DataHash
+	}
+	return metadata
+}
+
+func (m *Attachment) SchemaKey() string {
+	return m.GetMessageId() + "/" + m.GetPartId()
+}
+
+func (m *Attachment) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	if m.GetMessageId() == "" {
+		errs.Add("message_id", validate.ErrRequired)
+	}
+	if m.GetPartId() == "" {
+		errs.Add("part_id", validate.ErrRequired)
+	}
+	return errs.Err()
+}
+
 func (m *Email) SchemaKind() string {
 	return "Email"
 }
 
+func (m *Email) SchemaVersion() int {
+	return 2
+}
+
 func (m *Email) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
-	if m.From != "" {
-		metadata["from"] = m.From
-	}
-	if m.To != "" {
-		metadata["to"] = m.To
-	}
+	var fulltext []string
 	if m.Subject != "" {
 		metadata["subject"] = m.Subject
 	}
-	if m.Body != "" {
-		metadata["body"] = m.Body
-	}
-	if m.Date != "" {
-		metadata["date"] = m.Date
-	}
 	if m.MessageId != "" {
 		metadata["message_id"] = m.MessageId
 	}
@@ -33,43 +114,97 @@ func (m *Email) SchemaMetadata() map[string]interface{} {
 	if m.InReplyTo != "" {
 		metadata["in_reply_to"] = m.InReplyTo
 	}
-	if m.References != "" {
-		metadata["references"] = m.References
+	if m.Text != "" {
+		fulltext = append(fulltext, m.Text)
+	}
+	if m.From != nil {
+		if m.From.Name != "" {
+			metadata["from.name"] = m.From.Name
+		}
+		if m.From.Email != "" {
+			metadata["from.email"] = m.From.Email
+		}
+	}
+	if len(m.To) > 0 {
+		metadata["to"] = m.To
 	}
-	if m.Cc != "" {
+	if len(m.Cc) > 0 {
 		metadata["cc"] = m.Cc
 	}
-	if m.Bcc != "" {
+	if len(m.Bcc) > 0 {
 		metadata["bcc"] = m.Bcc
 	}
-	if m.Attachments != "" {
-		metadata["attachments"] = m.Attachments
+	if len(m.ReplyTo) > 0 {
+		metadata["reply_to"] = m.ReplyTo
 	}
-	if m.MimeType != "" {
-		metadata["mime_type"] = m.MimeType
+	if m.Date != nil {
+		metadata["date"] = m.Date.AsTime().UnixMilli()
+	}
+	if len(m.Labels) > 0 {
+		metadata["labels"] = m.Labels
 	}
-	if m.ContentType != "" {
-		metadata["content_type"] = m.ContentType
+	if m.Size != 0 {
+		metadata["size"] = m.Size
 	}
-	if m.Content != "" {
-		metadata["content"] = m.Content
+	if len(m.References) > 0 {
+		metadata["references"] = m.References
 	}
-	if m.Html != "" {
-		metadata["html"] = m.Html
+	if m.Snippet != "" {
+		metadata["snippet"] = m.Snippet
 	}
-	if m.Text != "" {
-		metadata["text"] = m.Text
+	if len(m.Attachments) > 0 {
+		metadata["attachments"] = m.Attachments
+	}
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
 	}
 	return metadata
 }
 
+func (m *Email) SchemaKey() string {
+	return m.GetMessageId()
+}
+
+func (m *Email) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	if m.GetMessageId() == "" {
+		errs.Add("message_id", validate.ErrRequired)
+	}
+	errs.Add("from", validate.Message(m.GetFrom()))
+	for n, v := range m.GetTo() {
+		errs.Add(validate.Index("to", n), validate.Message(v))
+	}
+	for n, v := range m.GetCc() {
+		errs.Add(validate.Index("cc", n), validate.Message(v))
+	}
+	for n, v := range m.GetBcc() {
+		errs.Add(validate.Index("bcc", n), validate.Message(v))
+	}
+	for n, v := range m.GetReplyTo() {
+		errs.Add(validate.Index("reply_to", n), validate.Message(v))
+	}
+	for n, v := range m.GetAttachments() {
+		errs.Add(validate.Index("attachments", n), validate.Message(v))
+	}
+	return errs.Err()
+}
+
 func (m *FinancialTransaction) SchemaKind() string {
 	return "FinancialTransaction"
 }
 
+func (m *FinancialTransaction) SchemaVersion() int {
+	return 1
+}
+
 func (m *FinancialTransaction) SchemaMetadata() map[string]interface{} {
 	metadata := make(map[string]interface{})
 
+	var fulltext []string
 	if m.Id != "" {
 		metadata["id"] = m.Id
 	}
@@ -95,10 +230,622 @@ func (m *FinancialTransaction) SchemaMetadata() map[string]interface{} {
 		metadata["subcategory"] = m.Subcategory
 	}
 	if m.Notes != "" {
-		metadata["notes"] = m.Notes
+		fulltext = append(fulltext, m.Notes)
 	}
 	if m.Type != "" {
 		metadata["type"] = m.Type
 	}
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
+	}
 	return metadata
 }
+
+func (m *FinancialTransaction) SchemaKey() string {
+	return m.GetId()
+}
+
+func (m *FinancialTransaction) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	return errs.Err()
+}
+
+func (m *Contact) SchemaKind() string {
+	return "Contact"
+}
+
+func (m *Contact) SchemaVersion() int {
+	return 1
+}
+
+func (m *Contact) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	var fulltext []string
+	if m.Id != "" {
+		metadata["id"] = m.Id
+	}
+	if m.Name != "" {
+		metadata["name"] = m.Name
+	}
+	if m.GivenName != "" {
+		metadata["given_name"] = m.GivenName
+	}
+	if m.FamilyName != "" {
+		metadata["family_name"] = m.FamilyName
+	}
+	if m.Nickname != "" {
+		metadata["nickname"] = m.Nickname
+	}
+	if len(m.Emails) > 0 {
+		metadata["emails"] = m.Emails
+	}
+	if len(m.Phones) > 0 {
+		metadata["phones"] = m.Phones
+	}
+	if len(m.Addresses) > 0 {
+		metadata["addresses"] = m.Addresses
+	}
+	if m.Organization != "" {
+		metadata["organization"] = m.Organization
+	}
+	if m.Title != "" {
+		metadata["title"] = m.Title
+	}
+	if m.Birthday != "" {
+		metadata["birthday"] = m.Birthday
+	}
+	if m.Notes != "" {
+		fulltext = append(fulltext, m.Notes)
+	}
+	if m.PhotoHash != "" {
+		metadata["photo_hash"] = m.PhotoHash
+	}
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
+	}
+	return metadata
+}
+
+func (m *Contact) SchemaKey() string {
+	return m.GetId()
+}
+
+func (m *Contact) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	for n, v := range m.GetEmails() {
+		errs.Add(validate.Index("emails", n), validate.Email(v))
+	}
+	return errs.Err()
+}
+
+func (m *CalendarEvent) SchemaKind() string {
+	return "CalendarEvent"
+}
+
+func (m *CalendarEvent) SchemaVersion() int {
+	return 1
+}
+
+func (m *CalendarEvent) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	var fulltext []string
+	if m.Id != "" {
+		metadata["id"] = m.Id
+	}
+	if m.Calendar != "" {
+		metadata["calendar"] = m.Calendar
+	}
+	if m.Title != "" {
+		metadata["title"] = m.Title
+	}
+	if m.Description != "" {
+		fulltext = append(fulltext, m.Description)
+	}
+	if m.Location != "" {
+		metadata["location"] = m.Location
+	}
+	if m.Start != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.Start); err == nil {
+				metadata["start"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.End != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.End); err == nil {
+				metadata["end"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.AllDay != false {
+		metadata["all_day"] = m.AllDay
+	}
+	if m.Organizer != "" {
+		metadata["organizer"] = m.Organizer
+	}
+	if len(m.Attendees) > 0 {
+		metadata["attendees"] = m.Attendees
+	}
+	if m.Recurrence != "" {
+		metadata["recurrence"] = m.Recurrence
+	}
+	if m.Status != "" {
+		metadata["status"] = m.Status
+	}
+	if m.Url != "" {
+		metadata["url"] = m.Url
+	}
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
+	}
+	return metadata
+}
+
+func (m *CalendarEvent) SchemaKey() string {
+	return m.GetId()
+}
+
+func (m *CalendarEvent) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	if m.GetStart() == "" {
+		errs.Add("start", validate.ErrRequired)
+	}
+	errs.Add("start", validate.Date(m.GetStart()))
+	errs.Add("end", validate.Date(m.GetEnd()))
+	errs.Add("url", validate.URL(m.GetUrl()))
+	return errs.Err()
+}
+
+func (m *MediaItem) SchemaKind() string {
+	return "MediaItem"
+}
+
+func (m *MediaItem) SchemaVersion() int {
+	return 1
+}
+
+func (m *MediaItem) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Id != "" {
+		metadata["id"] = m.Id
+	}
+	if m.Filename != "" {
+		metadata["filename"] = m.Filename
+	}
+	if m.MimeType != "" {
+		metadata["mime_type"] = m.MimeType
+	}
+	if m.TakenAt != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.TakenAt); err == nil {
+				metadata["taken_at"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.Width != 0 {
+		metadata["width"] = m.Width
+	}
+	if m.Height != 0 {
+		metadata["height"] = m.Height
+	}
+	if m.Size != 0 {
+		metadata["size"] = m.Size
+	}
+	if m.Duration != 0 {
+		metadata["duration"] = m.Duration
+	}
+	if m.Latitude != 0 {
+		metadata["latitude"] = m.Latitude
+	}
+	if m.Longitude != 0 {
+		metadata["longitude"] = m.Longitude
+	}
+	if m.Camera != "" {
+		metadata["camera"] = m.Camera
+	}
+	if m.Album != "" {
+		metadata["album"] = m.Album
+	}
+	if m.Description != "" {
+		metadata["description"] = m.Description
+	}
+	if m.DataHash != "" {
+		metadata["data_hash"] = m.DataHash
+	}
+	return metadata
+}
+
+func (m *MediaItem) SchemaKey() string {
+	return m.GetId()
+}
+
+func (m *MediaItem) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	errs.Add("taken_at", validate.Date(m.GetTakenAt()))
+	return errs.Err()
+}
+
+func (m *LocationPoint) SchemaKind() string {
+	return "LocationPoint"
+}
+
+func (m *LocationPoint) SchemaVersion() int {
+	return 1
+}
+
+func (m *LocationPoint) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.RecordedAt != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.RecordedAt); err == nil {
+				metadata["recorded_at"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.Latitude != 0 {
+		metadata["latitude"] = m.Latitude
+	}
+	if m.Longitude != 0 {
+		metadata["longitude"] = m.Longitude
+	}
+	if m.Altitude != 0 {
+		metadata["altitude"] = m.Altitude
+	}
+	if m.Accuracy != 0 {
+		metadata["accuracy"] = m.Accuracy
+	}
+	if m.Speed != 0 {
+		metadata["speed"] = m.Speed
+	}
+	if m.Heading != 0 {
+		metadata["heading"] = m.Heading
+	}
+	if m.Source != "" {
+		metadata["source"] = m.Source
+	}
+	if m.Device != "" {
+		metadata["device"] = m.Device
+	}
+	if m.Activity != "" {
+		metadata["activity"] = m.Activity
+	}
+	return metadata
+}
+
+func (m *LocationPoint) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	if m.GetRecordedAt() == "" {
+		errs.Add("recorded_at", validate.ErrRequired)
+	}
+	errs.Add("recorded_at", validate.Date(m.GetRecordedAt()))
+	return errs.Err()
+}
+
+func (m *Document) SchemaKind() string {
+	return "Document"
+}
+
+func (m *Document) SchemaVersion() int {
+	return 1
+}
+
+func (m *Document) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	var fulltext []string
+	if m.Path != "" {
+		metadata["path"] = m.Path
+	}
+	if m.Name != "" {
+		metadata["name"] = m.Name
+	}
+	if m.MimeType != "" {
+		metadata["mime_type"] = m.MimeType
+	}
+	if m.Size != 0 {
+		metadata["size"] = m.Size
+	}
+	if m.CreatedAt != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.CreatedAt); err == nil {
+				metadata["created_at"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.ModifiedAt != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.ModifiedAt); err == nil {
+				metadata["modified_at"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.Title != "" {
+		metadata["title"] = m.Title
+	}
+	if m.Author != "" {
+		metadata["author"] = m.Author
+	}
+	if m.Text != "" {
+		fulltext = append(fulltext, m.Text)
+	}
+	if m.DataHash != "" {
+		metadata["data_hash"] = m.DataHash
+	}
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
+	}
+	return metadata
+}
+
+func (m *Document) SchemaKey() string {
+	return m.GetPath()
+}
+
+func (m *Document) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	errs.Add("created_at", validate.Date(m.GetCreatedAt()))
+	errs.Add("modified_at", validate.Date(m.GetModifiedAt()))
+	return errs.Err()
+}
+
+func (m *HealthMetric) SchemaKind() string {
+	return "HealthMetric"
+}
+
+func (m *HealthMetric) SchemaVersion() int {
+	return 1
+}
+
+func (m *HealthMetric) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Type != "" {
+		metadata["type"] = m.Type
+	}
+	if m.Value != 0 {
+		metadata["value"] = m.Value
+	}
+	if m.Unit != "" {
+		metadata["unit"] = m.Unit
+	}
+	if m.Start != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.Start); err == nil {
+				metadata["start"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.End != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.End); err == nil {
+				metadata["end"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.Source != "" {
+		metadata["source"] = m.Source
+	}
+	if m.Device != "" {
+		metadata["device"] = m.Device
+	}
+	return metadata
+}
+
+func (m *HealthMetric) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	if m.GetType() == "" {
+		errs.Add("type", validate.ErrRequired)
+	}
+	if m.GetStart() == "" {
+		errs.Add("start", validate.ErrRequired)
+	}
+	errs.Add("start", validate.Date(m.GetStart()))
+	errs.Add("end", validate.Date(m.GetEnd()))
+	return errs.Err()
+}
+
+func (m *ChatMessage) SchemaKind() string {
+	return "ChatMessage"
+}
+
+func (m *ChatMessage) SchemaVersion() int {
+	return 1
+}
+
+func (m *ChatMessage) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	var fulltext []string
+	if m.Id != "" {
+		metadata["id"] = m.Id
+	}
+	if m.Service != "" {
+		metadata["service"] = m.Service
+	}
+	if m.ConversationId != "" {
+		metadata["conversation_id"] = m.ConversationId
+	}
+	if m.Conversation != "" {
+		metadata["conversation"] = m.Conversation
+	}
+	if m.Sender != "" {
+		metadata["sender"] = m.Sender
+	}
+	if len(m.Recipients) > 0 {
+		metadata["recipients"] = m.Recipients
+	}
+	if m.SentAt != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.SentAt); err == nil {
+				metadata["sent_at"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.Text != "" {
+		fulltext = append(fulltext, m.Text)
+	}
+	if m.ReplyTo != "" {
+		metadata["reply_to"] = m.ReplyTo
+	}
+	if len(m.Attachments) > 0 {
+		metadata["attachments"] = m.Attachments
+	}
+	if len(fulltext) > 0 {
+		metadata["fulltext"] = strings.Join(fulltext, "\n")
+	}
+	return metadata
+}
+
+func (m *ChatMessage) SchemaKey() string {
+	return m.GetId()
+}
+
+func (m *ChatMessage) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	errs.Add("sent_at", validate.Date(m.GetSentAt()))
+	return errs.Err()
+}
+
+func (m *Bookmark) SchemaKind() string {
+	return "Bookmark"
+}
+
+func (m *Bookmark) SchemaVersion() int {
+	return 1
+}
+
+func (m *Bookmark) SchemaMetadata() map[string]interface{} {
+	metadata := make(map[string]interface{})
+
+	if m.Url != "" {
+		metadata["url"] = m.Url
+	}
+	if m.Title != "" {
+		metadata["title"] = m.Title
+	}
+	if m.Description != "" {
+		metadata["description"] = m.Description
+	}
+	if len(m.Tags) > 0 {
+		metadata["tags"] = m.Tags
+	}
+	if m.Folder != "" {
+		metadata["folder"] = m.Folder
+	}
+	if m.CreatedAt != "" {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, m.CreatedAt); err == nil {
+				metadata["created_at"] = t.UnixMilli()
+				break
+			}
+		}
+	}
+	if m.ArchiveHash != "" {
+		metadata["archive_hash"] = m.ArchiveHash
+	}
+	return metadata
+}
+
+func (m *Bookmark) SchemaKey() string {
+	return m.GetUrl()
+}
+
+func (m *Bookmark) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	var errs validate.Errors
+	if m.GetUrl() == "" {
+		errs.Add("url", validate.ErrRequired)
+	}
+	errs.Add("url", validate.URL(m.GetUrl()))
+	errs.Add("created_at", validate.Date(m.GetCreatedAt()))
+	return errs.Err()
+}
+
+// RegisterSchemaKinds registers the kinds declared in rpc/schema.proto.
+func RegisterSchemaKinds(register func(kind string, desc protoreflect.MessageDescriptor, newFn func() protoreflect.ProtoMessage)) {
+	register("EmailAddress", File_rpc_schema_proto.Messages().ByName("EmailAddress"), func() protoreflect.ProtoMessage {
+		return new(EmailAddress)
+	})
+	register("Attachment", File_rpc_schema_proto.Messages().ByName("Attachment"), func() protoreflect.ProtoMessage {
+		return new(Attachment)
+	})
+	register("Email", File_rpc_schema_proto.Messages().ByName("Email"), func() protoreflect.ProtoMessage {
+		return new(Email)
+	})
+	register("FinancialTransaction", File_rpc_schema_proto.Messages().ByName("FinancialTransaction"), func() protoreflect.ProtoMessage {
+		return new(FinancialTransaction)
+	})
+	register("Contact", File_rpc_schema_proto.Messages().ByName("Contact"), func() protoreflect.ProtoMessage {
+		return new(Contact)
+	})
+	register("CalendarEvent", File_rpc_schema_proto.Messages().ByName("CalendarEvent"), func() protoreflect.ProtoMessage {
+		return new(CalendarEvent)
+	})
+	register("MediaItem", File_rpc_schema_proto.Messages().ByName("MediaItem"), func() protoreflect.ProtoMessage {
+		return new(MediaItem)
+	})
+	register("LocationPoint", File_rpc_schema_proto.Messages().ByName("LocationPoint"), func() protoreflect.ProtoMessage {
+		return new(LocationPoint)
+	})
+	register("Document", File_rpc_schema_proto.Messages().ByName("Document"), func() protoreflect.ProtoMessage {
+		return new(Document)
+	})
+	register("HealthMetric", File_rpc_schema_proto.Messages().ByName("HealthMetric"), func() protoreflect.ProtoMessage {
+		return new(HealthMetric)
+	})
+	register("ChatMessage", File_rpc_schema_proto.Messages().ByName("ChatMessage"), func() protoreflect.ProtoMessage {
+		return new(ChatMessage)
+	})
+	register("Bookmark", File_rpc_schema_proto.Messages().ByName("Bookmark"), func() protoreflect.ProtoMessage {
+		return new(Bookmark)
+	})
+}
@@ -20,22 +20,30 @@ func NewOauthConfig(inp *rpc.OAuth2) *oauth2.Config {
 	}
 }
 
+// NewOauthToken returns the token of inp. A token without an expiry never
+// expires.
 func NewOauthToken(inp *rpc.OAuth2) *oauth2.Token {
-	return &oauth2.Token{
+	token := &oauth2.Token{
 		AccessToken:  inp.Token.AccessToken,
 		TokenType:    inp.Token.TokenType,
 		RefreshToken: inp.Token.RefreshToken,
 		ExpiresIn:    inp.Token.ExpiresIn,
-		Expiry:       time.UnixMilli(inp.Token.Expiry),
 	}
+	if inp.Token.Expiry != 0 {
+		token.Expiry = time.UnixMilli(inp.Token.Expiry)
+	}
+	return token
 }
 
 func NewRPCOauthToken(inp *oauth2.Token) *rpc.OAuth2_Token {
-	return &rpc.OAuth2_Token{
+	token := &rpc.OAuth2_Token{
 		AccessToken:  inp.AccessToken,
 		TokenType:    inp.TokenType,
 		RefreshToken: inp.RefreshToken,
 		ExpiresIn:    inp.ExpiresIn,
-		Expiry:       inp.Expiry.UnixMilli(),
 	}
+	if !inp.Expiry.IsZero() {
+		token.Expiry = inp.Expiry.UnixMilli()
+	}
+	return token
 }
@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"bytes"
+	"encoding/json"
 	"time"
 
 	"go.quinn.io/dataq/hash"
@@ -16,12 +18,17 @@ type PluginInstance struct {
 	Oauth           *rpc.OAuth2          `json:"oauth,omitempty"`
 	InstallResponse *rpc.InstallResponse `json:"install_response,omitempty"`
 	Config          map[string]string    `json:"config,omitempty"`
+
+	// OauthRevoked is set when the refresh token was revoked, the instance
+	// can't extract until OAuth is connected again
+	OauthRevoked bool `json:"oauth_revoked,omitempty"`
 }
 
 func (p *PluginInstance) SchemaMetadata() map[string]interface{} {
 	return map[string]interface{}{
-		"plugin_id": p.PluginID,
-		"label":     p.Label,
+		"plugin_id":     p.PluginID,
+		"label":         p.Label,
+		"oauth_revoked": p.OauthRevoked,
 	}
 }
 
@@ -29,6 +36,44 @@ func (p *PluginInstance) SchemaKind() string {
 	return "PluginInstance"
 }
 
+// SyncState is the state a plugin instance keeps between extracts, e.g. the
+// cursor an incremental sync continues from. It is the content of a permanode
+// per instance, with a version for every update.
+type SyncState struct {
+	PluginID  string            `json:"plugin_id"`
+	Values    map[string][]byte `json:"values,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func (s *SyncState) SchemaMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"plugin_id":  s.PluginID,
+		"updated_at": s.UpdatedAt.UnixMilli(),
+	}
+}
+
+func (s *SyncState) SchemaKind() string {
+	return "SyncState"
+}
+
+// PluginBlob records that a plugin instance stored a blob, which it may read
+// back.
+type PluginBlob struct {
+	PluginID string `json:"plugin_id"`
+	Hash     string `json:"hash"`
+}
+
+func (p *PluginBlob) SchemaMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"plugin_id": p.PluginID,
+		"blob_hash": p.Hash,
+	}
+}
+
+func (p *PluginBlob) SchemaKind() string {
+	return "PluginBlob"
+}
+
 // Combined object for all types of claims
 type Claim struct {
 	// Type is "content", "permanode", "permanode_version", "delete"
@@ -51,18 +96,43 @@ type Claim struct {
 	TransformResponseHash string    `json:"transform_response_hash,omitempty"`
 	Timestamp             time.Time `json:"timestamp,omitzero"`
 
-	// Used by data_source
+	// Used by data_source and permanode_version of permanodes managed by a
+	// plugin. PluginID is the address of the PluginInstance.
 	PluginID  string `json:"plugin_id,omitempty"`
 	PluginKey string `json:"plugin_key,omitempty"`
 
 	// Used by delete
 	DeleteHash string `json:"delete_hash,omitempty"`
 
+	// Used by content and permanode_version. Version of the schema kind the
+	// content was stored with, 0 for content stored before versions were
+	// recorded, which is read as version 1.
+	SchemaVersion int `json:"schema_version,omitempty"`
+
+	// Used by content and permanode_version when the schema kind is not compiled
+	// into dataq. Address of the descriptor set describing the content.
+	DescriptorHash string `json:"descriptor_hash,omitempty"`
+
 	// Not stored in CAS, they are already stored in the referenced object
 	// Useful for using search results from the index without unmarshalling the claimed object
 	Metadata map[string]interface{} `json:"-"`
 }
 
+// IsClaim reports whether a CAS object is a claim rather than content. Claims
+// are JSON objects with a dataq_type. Keys are sorted in canonical JSON, so
+// dataq_type is not necessarily the first one.
+func IsClaim(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '{' || !bytes.Contains(b, []byte(`"dataq_type"`)) {
+		return false
+	}
+
+	var claim struct {
+		Type string `json:"dataq_type"`
+	}
+	return json.Unmarshal(b, &claim) == nil && claim.Type != ""
+}
+
 // Below are the types of claims. May not use any of these structs, for now. Instead use Claim struct above.
 
 // Content is immutable content. Exclusive to permanode.
@@ -126,6 +196,19 @@ type DataSource struct {
 	PluginKey     string `json:"plugin_key"`
 }
 
+// DataSource claims are indexed under their own kind, so that the permanode of
+// a plugin key can be found
+func (d *DataSource) SchemaKind() string {
+	return "DataSource"
+}
+
+func (d *DataSource) SchemaMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"plugin_id":  d.PluginID,
+		"plugin_key": d.PluginKey,
+	}
+}
+
 func NewDataSource(permanodeHash, pluginID, pluginKey string) *Claim {
 	return &Claim{
 		Type:          "data_source",
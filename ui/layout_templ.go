@@ -60,7 +60,7 @@ func Layout() templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"></head><body class=\"font-mono dark:bg-black dark:text-white min-h-full\"><div class=\"bg-slate-400 p-3 flex justify-between\"><a href=\"/\">dataq</a><nav><a href=\"/plugin/install\" class=\"underline\">install plugin</a></nav></div><div class=\"p-3\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"></head><body class=\"font-mono dark:bg-black dark:text-white min-h-full\"><div class=\"bg-slate-400 p-3 flex justify-between\"><a href=\"/\">dataq</a><nav><a href=\"/search\" class=\"underline\">search</a> <a href=\"/plugin/install\" class=\"underline\">install plugin</a> <a href=\"/plugin/status\" class=\"underline\">plugin status</a></nav></div><div class=\"p-3\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -87,7 +87,7 @@ func Layout() templ.Component {
 			var templ_7745c5c3_Var5 string
 			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(crumb.Name)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/layout.templ`, Line: 55, Col: 54}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `ui/layout.templ`, Line: 57, Col: 54}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
@@ -0,0 +1,124 @@
+// Package validate has the checks used by the Validate methods generated by
+// protoc-gen-dataq-index from (dataq.validate) field options.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrRequired = errors.New("required")
+
+// FieldError is a field that failed validation
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors collects the field errors of a message
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for n, err := range e {
+		s[n] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Add records err for field. The errors of a nested message are added with
+// field as a prefix. nil errors are ignored.
+func (e *Errors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	var nested Errors
+	if errors.As(err, &nested) {
+		for _, n := range nested {
+			*e = append(*e, &FieldError{Field: field + "." + n.Field, Err: n.Err})
+		}
+		return
+	}
+
+	*e = append(*e, &FieldError{Field: field, Err: err})
+}
+
+// Err returns nil if there are no errors
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Index names an element of a repeated field
+func Index(field string, n int) string {
+	return fmt.Sprintf("%s[%d]", field, n)
+}
+
+// Message validates a nested message if it has a Validate method
+func Message(m any) error {
+	if v, ok := m.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// The format checks accept empty values, use required to reject them.
+
+// Email checks for a bare address like alice@example.com
+func Email(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return fmt.Errorf("invalid email address: %q", s)
+	}
+	return nil
+}
+
+// DateLayouts are the accepted formats of dates, the same ones TIMESTAMP
+// fields are parsed with
+var DateLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// Date checks for an RFC 3339 time or a date
+func Date(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	for _, layout := range DateLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date: %q", s)
+}
+
+// URL checks for an absolute URL
+func URL(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid URL: %q", s)
+	}
+	return nil
+}